@@ -15,9 +15,12 @@
 package main
 
 import (
+	"errors"
 	"flag"
+	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
 
 	"github.com/dave/jennifer/jen"
 	"github.com/rs/zerolog"
@@ -26,32 +29,179 @@ import (
 )
 
 func main() {
-	var flags flag.FlagSet
-	loglevel := flags.Int("loglevel", 1, "loglevel available at https://pkg.go.dev/github.com/rs/zerolog@v1.28.0?utm_source=gopls#Level")
+	flags, run := plugin()
 	protogen.Options{
 		ParamFunc: flags.Set,
-	}.Run(func(gen *protogen.Plugin) error {
+	}.Run(run)
+}
+
+// plugin returns the plugin's flags, set from the parameters passed with --terraform_opt, and the function that
+// generates the response to a request once they have been set.
+func plugin() (*flag.FlagSet, func(gen *protogen.Plugin) error) {
+	flags := &flag.FlagSet{}
+	loglevel := flags.Int("loglevel", 1, "loglevel available at https://pkg.go.dev/github.com/rs/zerolog@v1.28.0?utm_source=gopls#Level")
+	configFile := flags.String("config", "", "project wide config file, relative to the directory protoc is run from")
+	checkFile := flags.String("check", "", "snapshot to check the generated schemas against, failing on changes that break existing Terraform configurations")
+	snapshotFile := flags.String("snapshot", "", "snapshot of the generated schemas used to upgrade state from prior versions, read relative to the directory protoc is run from and written relative to the output directory")
+	schemaJSON := flags.String("schema_json", "", "provider source address, e.g. registry.terraform.io/mycorp/widgets, to output a _terraform.schema.json file per proto file in the terraform providers schema -json format")
+	selective := flags.Bool("selective", false, "only generate messages with the (terraform.resource) option, the +terraform-gen:resource comment tag or a full name matching an include pattern")
+	var include stringList
+	flags.Var(&include, "include", "message full name or pattern to generate when generating selectively, may be repeated, implies selective")
+	tests := flags.Bool("tests", false, "output a _terraform_test.go file per proto file that checks each message's schema is valid, maps every field and round trips through the copy functions")
+	var protoPaths stringList
+	flags.Var(&protoPaths, "proto_path", "directory proto files are imported from, like protoc's -I, used to find configs referenced by comments next to their proto file, may be repeated, defaults to the directory protoc is run from")
+	examples := flags.String("examples", "", "provider source address, e.g. registry.terraform.io/mycorp/widgets, to output an <examples_dir>/resources/<resource type>/resource.tf file per resource message")
+	examplesDir := flags.String("examples_dir", "examples", "directory examples are written to, relative to the output directory")
+	return flags, func(gen *protogen.Plugin) error {
 		zerolog.SetGlobalLevel(zerolog.Level(*loglevel))
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+		cfg, err := generate.LoadConfig(*configFile)
+		if err != nil {
+			gen.Error(err)
+			return nil
+		}
+		if *selective || len(include) > 0 {
+			if err := cfg.Selective(include); err != nil {
+				gen.Error(err)
+				return nil
+			}
+		}
+		cfg.ProtoPaths(protoPaths)
+		cfg.Index(gen.Files)
+		if *snapshotFile != "" {
+			if err := cfg.LoadSnapshot(*snapshotFile); err != nil {
+				gen.Error(err)
+				return nil
+			}
+		}
+		// Keep going after a file fails so every problem is reported in a single run.
+		var errs []string
 		for _, f := range gen.Files {
 			if !f.Generate {
 				continue
 			}
-			generateFile(gen, f)
+			if err := generateFile(gen, f, cfg); err != nil {
+				errs = append(errs, err.Error())
+			}
+			if *examples != "" {
+				if err := generateExamples(gen, f, cfg, *examples, *examplesDir); err != nil {
+					errs = append(errs, err.Error())
+				}
+			}
+			if *tests {
+				generateTests(gen, f, cfg)
+			}
+			if *schemaJSON != "" {
+				if err := generateSchemaJSON(gen, f, cfg, *schemaJSON); err != nil {
+					errs = append(errs, err.Error())
+				}
+			}
+		}
+		// Invalid message configs are reported once, however many files and generators use them.
+		if err := cfg.ConfigErrors(); err != nil {
+			errs = append([]string{err.Error()}, errs...)
+		}
+		if len(errs) > 0 {
+			gen.Error(errors.New(strings.Join(errs, "\n")))
+			return nil
+		}
+		if *checkFile != "" {
+			if err := cfg.Check(*checkFile, gen.Files); err != nil {
+				gen.Error(err)
+				return nil
+			}
+		}
+		if *snapshotFile != "" {
+			contents, err := cfg.Snapshot(gen.Files)
+			if err != nil {
+				gen.Error(err)
+				return nil
+			}
+			if _, err := gen.NewGeneratedFile(*snapshotFile, "").Write(contents); err != nil {
+				gen.Error(err)
+			}
 		}
 		return nil
-	})
+	}
 }
 
-// generateFile generates a _ascii.pb.go file containing gRPC service definitions.
-func generateFile(gen *protogen.Plugin, file *protogen.File) {
+// generateFile generates a _terraform.go file containing the schema definitions and copy functions for every message
+// in the file, and helpers to wait for the file's long-running operations.
+func generateFile(gen *protogen.Plugin, file *protogen.File, cfg *generate.Config) error {
 	filename := file.GeneratedFilenamePrefix + "_terraform.go"
 
-	f := jen.NewFile(string(file.GoPackageName))
-	for _, m := range file.Messages {
-		generate.Scheme(f, m)
+	f := jen.NewFilePathName(string(file.GoImportPath), string(file.GoPackageName))
+	var errs []string
+	for _, m := range cfg.GeneratedMessages(file) {
+		if err := generate.Scheme(f, m, cfg); err != nil {
+			errs = append(errs, err.Error())
+		}
+		generate.ResourceName(f, m)
+		generate.ImportState(f, m)
+		generate.UpdateMask(f, m, cfg)
+		generate.Copy(f, m, cfg)
+		generate.Timeouts(f, m, cfg)
+		generate.UpgradeState(f, m, cfg)
+	}
+	generate.AttributeHelpers(f, file, cfg)
+	if err := generate.Operations(f, file, cfg); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
 	}
 
 	g := gen.NewGeneratedFile(filename, file.GoImportPath)
 	g.P("// Code generated by protoc-gen-terraform. DO NOT EDIT.")
 	g.P(f.GoString())
+	return nil
+}
+
+// generateTests generates a _terraform_test.go file containing a test of every message in the file.
+func generateTests(gen *protogen.Plugin, file *protogen.File, cfg *generate.Config) {
+	f := jen.NewFilePathName(string(file.GoImportPath), string(file.GoPackageName))
+	for _, m := range cfg.GeneratedMessages(file) {
+		generate.Tests(f, m, cfg)
+	}
+	g := gen.NewGeneratedFile(file.GeneratedFilenamePrefix+"_terraform_test.go", file.GoImportPath)
+	g.P("// Code generated by protoc-gen-terraform. DO NOT EDIT.")
+	g.P(f.GoString())
+}
+
+// generateSchemaJSON generates a _terraform.schema.json file containing the schema of every message in the file,
+// as resources of provider, in the terraform providers schema -json format.
+func generateSchemaJSON(gen *protogen.Plugin, file *protogen.File, cfg *generate.Config, provider string) error {
+	contents, err := generate.SchemaJSON(file, cfg, provider)
+	if err != nil {
+		return err
+	}
+	_, err = gen.NewGeneratedFile(file.GeneratedFilenamePrefix+"_terraform.schema.json", "").Write(contents)
+	return err
+}
+
+// generateExamples generates an example configuration of every resource message in the file, as resources of provider,
+// under dir, where tfplugindocs looks for them when it is examples.
+func generateExamples(gen *protogen.Plugin, file *protogen.File, cfg *generate.Config, provider, dir string) error {
+	examples, err := generate.Examples(file, cfg, provider, dir)
+	if err != nil {
+		return err
+	}
+	for filename, contents := range examples {
+		if _, err := gen.NewGeneratedFile(filename, "").Write(contents); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stringList is a flag that can be repeated, e.g. --terraform_opt=include=a.*,include=b.*.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
 }
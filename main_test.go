@@ -0,0 +1,118 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata/*/golden")
+
+// TestGolden runs the plugin in-process for every directory in testdata, without protoc, and compares the response
+// with the files in its golden directory. Each directory has:
+//
+//   - descriptor_set.pb: the protos compiled with protoc --include_imports --include_source_info --descriptor_set_out
+//   - params: the plugin parameter, e.g. paths=source_relative,tests=true
+//   - golden: the generated files, or an error file if generation fails, with a .golden suffix so formatters and
+//     the go tool leave them alone
+//
+// Code is generated for the protos in the directory. Run with -update to rewrite the golden files.
+func TestGolden(t *testing.T) {
+	dirs, err := ioutil.ReadDir("testdata")
+	require.NoError(t, err)
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		dir := filepath.Join("testdata", dir.Name())
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			got := generateGolden(t, dir)
+			golden := filepath.Join(dir, "golden")
+			if *update {
+				require.NoError(t, os.RemoveAll(golden))
+				for name, contents := range got {
+					path := filepath.Join(golden, filepath.FromSlash(name)+".golden")
+					require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+					require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0o644))
+				}
+				return
+			}
+			want := map[string]string{}
+			require.NoError(t, filepath.Walk(golden, func(path string, info os.FileInfo, err error) error {
+				if err != nil || info.IsDir() {
+					return err
+				}
+				contents, err := ioutil.ReadFile(path)
+				name, _ := filepath.Rel(golden, path)
+				want[strings.TrimSuffix(filepath.ToSlash(name), ".golden")] = string(contents)
+				return err
+			}), "run go test -run TestGolden -update to create the golden files")
+			for name := range want {
+				require.Contains(t, got, name, "%s is no longer generated, run go test -run TestGolden -update if that's expected", name)
+			}
+			for name, contents := range got {
+				require.Contains(t, want, name, "%s is generated but has no golden file, run go test -run TestGolden -update if that's expected", name)
+				require.Equal(t, want[name], contents, "%s differs from its golden file, run go test -run TestGolden -update if that's expected", name)
+			}
+		})
+	}
+}
+
+// generateGolden runs the plugin on the test case in dir and returns the generated files keyed by name, or an error
+// file if it fails.
+func generateGolden(t *testing.T, dir string) map[string]string {
+	contents, err := ioutil.ReadFile(filepath.Join(dir, "descriptor_set.pb"))
+	require.NoError(t, err)
+	set := &descriptorpb.FileDescriptorSet{}
+	require.NoError(t, proto.Unmarshal(contents, set))
+	params, err := ioutil.ReadFile(filepath.Join(dir, "params"))
+	require.NoError(t, err)
+
+	req := &pluginpb.CodeGeneratorRequest{
+		Parameter: proto.String(strings.TrimSpace(string(params))),
+		ProtoFile: set.File,
+	}
+	for _, file := range set.File {
+		if _, err := os.Stat(filepath.Join(dir, file.GetName())); err == nil {
+			req.FileToGenerate = append(req.FileToGenerate, file.GetName())
+		}
+	}
+	require.NotEmpty(t, req.FileToGenerate, "descriptor_set.pb has none of the protos in %s", dir)
+
+	flags, run := plugin()
+	gen, err := protogen.Options{ParamFunc: flags.Set}.New(req)
+	require.NoError(t, err)
+	require.NoError(t, run(gen))
+	resp := gen.Response()
+	if resp.Error != nil {
+		return map[string]string{"error": resp.GetError() + "\n"}
+	}
+	files := map[string]string{}
+	for _, file := range resp.File {
+		files[file.GetName()] = file.GetContent()
+	}
+	return files
+}
@@ -0,0 +1,159 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	j "github.com/dave/jennifer/jen"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// attribute is a schema attribute. Scheme builds a tree of them from the message's fields, annotations and config,
+// which is rendered as a tfsdk.Attribute and recorded in snapshots.
+type attribute struct {
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Optional    bool   `json:"optional,omitempty"`
+	Computed    bool   `json:"computed,omitempty"`
+	Sensitive   bool   `json:"sensitive,omitempty"`
+
+	// Type is set for typed attributes, Nesting and Attributes for nested ones.
+	Type       *attributeType        `json:"type,omitempty"`
+	Nesting    string                `json:"nesting,omitempty"`
+	Attributes map[string]*attribute `json:"attributes,omitempty"`
+
+	// Field is the name of the proto field the attribute was generated from, if any.
+	Field string `json:"field,omitempty"`
+
+	// enum is the enum the field holds, if any.
+	enum protoreflect.EnumDescriptor
+	// example overrides the placeholder value used in example configurations.
+	example string
+	// message is the message nested attributes were generated from, helper the attrs<Message> or
+	// GenAttributes<Message> function that renders them instead of inlining them, if any.
+	message *protogen.Message
+	helper  protogen.GoIdent
+
+	validators    []j.Code
+	planModifiers []j.Code
+}
+
+// attributeType is a Terraform type. Kind is one of bool, float64, int64, number, string, list, map, set or object.
+type attributeType struct {
+	Kind      string                    `json:"kind"`
+	ElemType  *attributeType            `json:"elemType,omitempty"`
+	AttrTypes map[string]*attributeType `json:"attrTypes,omitempty"`
+
+	// custom is a custom attr.Type rendered instead of the framework type of Kind.
+	custom *j.Statement
+}
+
+// attributeKinds maps attribute type kinds to framework types.
+var attributeKinds = map[string]string{
+	"bool":    "BoolType",
+	"float64": "Float64Type",
+	"int64":   "Int64Type",
+	"number":  "NumberType",
+	"string":  "StringType",
+	"list":    "ListType",
+	"map":     "MapType",
+	"set":     "SetType",
+	"object":  "ObjectType",
+}
+
+// code renders the attribute as a tfsdk.Attribute literal.
+func (a *attribute) code() j.Code {
+	d := j.Dict{}
+	// Attributes generated from fields always have a description, even if the field isn't commented.
+	if a.Description != "" || a.Field != "" {
+		d[j.Id("Description")] = j.Lit(a.Description)
+	}
+	for _, flag := range []struct {
+		name  string
+		value bool
+	}{{"Required", a.Required}, {"Optional", a.Optional}, {"Computed", a.Computed}, {"Sensitive", a.Sensitive}} {
+		if flag.value {
+			d[j.Id(flag.name)] = j.Lit(true)
+		}
+	}
+	if a.Type != nil {
+		d[j.Id("Type")] = a.Type.code()
+	}
+	if a.helper.GoName != "" {
+		d[j.Id("Attributes")] = j.Qual(SDK, goName(a.Nesting)+"NestedAttributes").Params(j.Qual(string(a.helper.GoImportPath), a.helper.GoName).Call())
+	} else if a.Attributes != nil {
+		d[j.Id("Attributes")] = j.Qual(SDK, goName(a.Nesting)+"NestedAttributes").Params(attributesCode(a.Attributes))
+	}
+	if len(a.validators) > 0 {
+		d[j.Id("Validators")] = j.Index().Qual(SDK, "AttributeValidator").Values(a.validators...)
+	}
+	if len(a.planModifiers) > 0 {
+		d[j.Id("PlanModifiers")] = j.Qual(SDK, "AttributePlanModifiers").Values(a.planModifiers...)
+	}
+	return j.Values(d)
+}
+
+// attributesCode renders attributes as a map[string]tfsdk.Attribute literal.
+func attributesCode(attrs map[string]*attribute) *j.Statement {
+	d := j.Dict{}
+	for name, attr := range attrs {
+		d[j.Lit(name)] = attr.code()
+	}
+	return j.Map(j.String()).Qual(SDK, "Attribute").Values(d)
+}
+
+// code renders the type as a framework attr.Type.
+func (t *attributeType) code() *j.Statement {
+	if t.custom != nil {
+		return t.custom.Clone()
+	}
+	switch t.Kind {
+	case "list", "map", "set":
+		return j.Qual(Types, attributeKinds[t.Kind]).Values(j.Dict{j.Id("ElemType"): t.ElemType.code()})
+	case "object":
+		if len(t.AttrTypes) == 0 {
+			return j.Qual(Types, "ObjectType").Values()
+		}
+		attrTypes := j.Dict{}
+		for name, typ := range t.AttrTypes {
+			attrTypes[j.Lit(name)] = typ.code()
+		}
+		return j.Qual(Types, "ObjectType").Values(j.Dict{
+			j.Id("AttrTypes"): j.Map(j.String()).Qual(Attr, "Type").Values(attrTypes),
+		})
+	}
+	return j.Qual(Types, attributeKinds[t.Kind])
+}
+
+// String describes the type, e.g. list(string).
+func (t *attributeType) String() string {
+	if t == nil {
+		return "unknown"
+	}
+	switch t.Kind {
+	case "list", "map", "set":
+		return t.Kind + "(" + t.ElemType.String() + ")"
+	case "object":
+		s := "object({"
+		for i, name := range sortedKeys(t.AttrTypes) {
+			if i > 0 {
+				s += ", "
+			}
+			s += name + " = " + t.AttrTypes[name].String()
+		}
+		return s + "})"
+	}
+	return t.Kind
+}
@@ -0,0 +1,130 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Check compares the current schema of every message in files that are being generated against the latest version of
+// it in the snapshot, failing on changes that would break existing Terraform configurations.
+// Messages that aren't in the snapshot are new so can't break anything.
+func (c *Config) Check(filename string, files []*protogen.File) error {
+	s, err := readSnapshot(filename)
+	if err != nil {
+		return err
+	}
+	errs := schemaErrors{}
+	for _, file := range files {
+		if !file.Generate {
+			continue
+		}
+		for _, m := range c.GeneratedMessages(file) {
+			versions := s.Messages[string(m.Desc.FullName())]
+			if len(versions) == 0 {
+				continue
+			}
+			latest := int64(-1)
+			for v := range versions {
+				if v > latest {
+					latest = v
+				}
+			}
+
+			l := log.With().Str("generator", "Check").Str("proto", m.GoIdent.GoName).Logger()
+			current, err := schemaAttributes(l, c, m)
+			if err != nil {
+				errs.add(err)
+				continue
+			}
+			for _, change := range breakingChanges("", versions[latest].Attributes, current) {
+				errs = append(errs, fmt.Sprintf("%s: breaking change to %s since version %d in %s: %s", location(m.Desc), m.Desc.FullName(), latest, filename, change))
+			}
+		}
+	}
+	return errs.err()
+}
+
+// breakingChanges describes every change from prior to current that would break configurations written for prior:
+// removed or renamed attributes, new required attributes, attributes that became required or can no longer be set,
+// and changes to types or nesting.
+func breakingChanges(prefix string, prior, current map[string]*attribute) []string {
+	changes := []string{}
+	for _, name := range sortedKeys(prior) {
+		p := prior[name]
+		attr, ok := current[name]
+		if !ok {
+			// Follow renames so the new name can be reported.
+			if renamed, found := currentAttribute(p, current); found {
+				changes = append(changes, fmt.Sprintf("attribute %q was renamed to %q", prefix+name, prefix+renamed))
+			} else {
+				changes = append(changes, fmt.Sprintf("attribute %q was removed", prefix+name))
+			}
+			continue
+		}
+
+		configurable := p.Required || p.Optional
+		switch {
+		case !p.Required && attr.Required:
+			changes = append(changes, fmt.Sprintf("attribute %q is now required", prefix+name))
+		case configurable && !attr.Required && !attr.Optional:
+			changes = append(changes, fmt.Sprintf("attribute %q can no longer be set", prefix+name))
+		}
+
+		switch {
+		case (p.Attributes == nil) != (attr.Attributes == nil):
+			changes = append(changes, fmt.Sprintf("attribute %q changed from %s to %s", prefix+name, p.shape(), attr.shape()))
+		case p.Attributes != nil:
+			if p.Nesting != attr.Nesting {
+				changes = append(changes, fmt.Sprintf("attribute %q changed from %s to %s", prefix+name, p.shape(), attr.shape()))
+				continue
+			}
+			changes = append(changes, breakingChanges(prefix+name+".", p.Attributes, attr.Attributes)...)
+		case p.Type.String() != attr.Type.String():
+			changes = append(changes, fmt.Sprintf("attribute %q changed type from %s to %s", prefix+name, p.Type, attr.Type))
+		}
+	}
+	for _, name := range sortedKeys(current) {
+		if _, ok := prior[name]; !ok && current[name].Required {
+			changes = append(changes, fmt.Sprintf("attribute %q is new and required", prefix+name))
+		}
+	}
+	return changes
+}
+
+// currentAttribute returns the name of the current attribute generated from the same field as the prior one.
+func currentAttribute(prior *attribute, current map[string]*attribute) (string, bool) {
+	if prior.Field == "" {
+		return "", false
+	}
+	for _, name := range sortedKeys(current) {
+		if current[name].Field == prior.Field {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// shape describes the attribute's type or nesting, e.g. list(string) or list nested attributes.
+func (a *attribute) shape() string {
+	if a.Attributes != nil {
+		return a.Nesting + " nested attributes"
+	}
+	return a.Type.String()
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakingChanges(t *testing.T) {
+	str := &attributeType{Kind: "string"}
+	prior := map[string]*attribute{
+		"removed":     {Optional: true, Type: str, Field: "removed"},
+		"old_name":    {Optional: true, Type: str, Field: "renamed"},
+		"required":    {Optional: true, Type: str},
+		"computed":    {Optional: true, Computed: true, Type: str},
+		"retyped":     {Optional: true, Type: &attributeType{Kind: "list", ElemType: str}},
+		"unchanged":   {Required: true, Type: str},
+		"relaxed":     {Required: true, Type: str},
+		"reshaped":    {Optional: true, Nesting: "single", Attributes: map[string]*attribute{}},
+		"nested":      {Optional: true, Nesting: "list", Attributes: map[string]*attribute{"a": {Optional: true, Type: str}}},
+		"nested_type": {Optional: true, Nesting: "list", Attributes: map[string]*attribute{}},
+	}
+	current := map[string]*attribute{
+		"new_name":    {Optional: true, Type: str, Field: "renamed"},
+		"required":    {Required: true, Type: str},
+		"computed":    {Computed: true, Type: str},
+		"retyped":     {Optional: true, Type: &attributeType{Kind: "set", ElemType: str}},
+		"unchanged":   {Required: true, Type: str},
+		"relaxed":     {Optional: true, Type: str},
+		"reshaped":    {Optional: true, Nesting: "map", Attributes: map[string]*attribute{}},
+		"nested":      {Optional: true, Nesting: "list", Attributes: map[string]*attribute{"a": {Optional: true, Type: &attributeType{Kind: "int64"}}}},
+		"nested_type": {Optional: true, Type: &attributeType{Kind: "list", ElemType: &attributeType{Kind: "object"}}},
+		"added":       {Optional: true, Type: str},
+		"mandatory":   {Required: true, Type: str},
+	}
+	require.Equal(t, []string{
+		`attribute "computed" can no longer be set`,
+		`attribute "nested.a" changed type from string to int64`,
+		`attribute "nested_type" changed from list nested attributes to list(object({}))`,
+		`attribute "old_name" was renamed to "new_name"`,
+		`attribute "removed" was removed`,
+		`attribute "required" is now required`,
+		`attribute "reshaped" changed from single nested attributes to map nested attributes`,
+		`attribute "retyped" changed type from list(string) to set(string)`,
+		`attribute "mandatory" is new and required`,
+	}, breakingChanges("", prior, current))
+}
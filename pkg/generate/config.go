@@ -15,47 +15,322 @@
 package generate
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"io/ioutil"
 	"path"
 	"regexp"
+	"sort"
 
 	"gopkg.in/yaml.v3"
 
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
-var configMatch = regexp.MustCompile(`\+terraform-gen:config:([^\/]+\.yaml|[^\/]+\.yml)`)
+var (
+	configMatch   = regexp.MustCompile(`\+terraform-gen:config:([^\/]+\.yaml|[^\/]+\.yml)`)
+	attributeName = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+)
+
+// Config is the project wide generator config, passed with --terraform_opt=config=<file>.
+type Config struct {
+	// Defaults apply to every message.
+	Defaults config `yaml:"defaults,omitempty"`
+	// Messages apply to every message whose full name matches.
+	Messages []messageRule `yaml:"messages,omitempty"`
+	// TypeMappings map full message names to a single attribute used wherever the message is a field,
+	// instead of nested attributes.
+	TypeMappings map[string]typeMapping `yaml:"typeMappings,omitempty"`
+	// JSONMessages are full message names or globs of messages rendered as a JSON encoded string, like
+	// google.protobuf.Struct.
+	JSONMessages []string `yaml:"jsonMessages,omitempty"`
+	// OperationTimeouts map full RPC names, e.g. mycorp.v1.Widgets.BatchCreateWidgets, to the timeout that bounds
+	// waiting on their long-running operation. They override the timeout matching the start of the RPC's name.
+	OperationTimeouts map[string]string `yaml:"operationTimeouts,omitempty"`
+
+	// messages, operationResponses and helpers are populated by Index.
+	messages           map[protoreflect.FullName]*protogen.Message
+	operationResponses map[protoreflect.FullName]bool
+	helpers            map[protoreflect.FullName]protogen.GoIdent
+	// snapshot is populated by LoadSnapshot.
+	snapshot *snapshot
+	// selective and include are set by Selective.
+	selective bool
+	include   []string
+	// protoPaths is set by ProtoPaths.
+	protoPaths []string
+	// configs and configErrs are populated by messageConfig, so each message's config is read once.
+	configs    map[protoreflect.FullName]config
+	configErrs schemaErrors
+}
+
+// ProtoPaths sets the directories, relative to the directory protoc is run from, that proto files were imported from
+// (protoc's -I flags). Configs referenced by a message's comments are looked for next to its source file in each of
+// them, so messages imported from other directories can reference configs too.
+func (c *Config) ProtoPaths(paths []string) {
+	c.protoPaths = paths
+}
+
+type messageRule struct {
+	// Match is either a full message name (mycorp.v1.Widget) or a glob (mycorp.v1.*).
+	Match  string `yaml:"match"`
+	config `yaml:",inline"`
+}
 
 type config struct {
 	InjectedFields map[string]injectedField `yaml:"injectedFields,omitempty"`
+	// Rename maps proto field names to the attribute name to use instead of the snake cased field name.
+	Rename map[string]string `yaml:"rename,omitempty"`
+	// Exclude lists proto field names that are left out of the schema.
+	Exclude []string `yaml:"exclude,omitempty"`
+	// Timeouts adds a timeouts attribute with the given default durations.
+	Timeouts *timeoutsConfig `yaml:"timeouts,omitempty"`
+	// Version is the schema version, increment it when a change needs existing state to be upgraded.
+	Version int64 `yaml:"version,omitempty"`
+	// CustomTypes maps proto field names to a custom attr.Type used instead of the framework type,
+	// e.g. github.com/mycorp/tftypes.JSONType{}.
+	CustomTypes map[string]string `yaml:"customTypes,omitempty"`
+	// EnumTypes gives enum fields a runtime.EnumType that only accepts the enum's values.
+	EnumTypes bool `yaml:"enumTypes,omitempty"`
+	// JSONStrings lists proto string fields holding JSON, which get a runtime.JSONStringType.
+	JSONStrings []string `yaml:"jsonStrings,omitempty"`
+}
+
+func (c config) validate() error {
+	errs := schemaErrors{}
+	for _, name := range sortedKeys(c.InjectedFields) {
+		errs.add(prefixErrors("injectedFields."+name, c.InjectedFields[name].validate()))
+	}
+	for _, field := range sortedKeys(c.Rename) {
+		if !attributeName.MatchString(c.Rename[field]) {
+			errs = append(errs, fmt.Sprintf("rename.%s: %q is not a valid attribute name", field, c.Rename[field]))
+		}
+	}
+	if c.Timeouts != nil {
+		errs.add(c.Timeouts.validate())
+	}
+	for _, field := range sortedKeys(c.CustomTypes) {
+		if _, _, ok := customType(c.CustomTypes[field]); !ok {
+			errs = append(errs, fmt.Sprintf("customTypes.%s: %q must be a fully qualified type or variable, e.g. github.com/mycorp/tftypes.JSONType{}", field, c.CustomTypes[field]))
+		}
+	}
+	for i, field := range c.JSONStrings {
+		if _, ok := c.CustomTypes[field]; ok {
+			errs = append(errs, fmt.Sprintf("jsonStrings[%d]: %s also has a custom type", i, field))
+		}
+	}
+	if c.Version < 0 {
+		errs = append(errs, fmt.Sprintf("version: %d must not be negative", c.Version))
+	}
+	return errs.err()
+}
+
+func (c *Config) validate() error {
+	errs := schemaErrors{}
+	errs.add(prefixErrors("defaults", c.Defaults.validate()))
+	for i, rule := range c.Messages {
+		prefix := fmt.Sprintf("messages[%d]", i)
+		if _, err := path.Match(rule.Match, ""); rule.Match == "" || err != nil {
+			errs = append(errs, fmt.Sprintf("%s.match: %q is not a valid message name or pattern", prefix, rule.Match))
+		}
+		errs.add(prefixErrors(prefix, rule.config.validate()))
+	}
+	for _, name := range sortedKeys(c.TypeMappings) {
+		errs.add(prefixErrors("typeMappings."+name, c.TypeMappings[name].validate()))
+	}
+	for i, pattern := range c.JSONMessages {
+		if _, err := path.Match(pattern, ""); pattern == "" || err != nil {
+			errs = append(errs, fmt.Sprintf("jsonMessages[%d]: %q is not a valid message name or pattern", i, pattern))
+		}
+	}
+	for _, method := range sortedKeys(c.OperationTimeouts) {
+		valid := false
+		for _, op := range timeoutOperations {
+			valid = valid || op.timeout == c.OperationTimeouts[method]
+		}
+		if !valid {
+			errs = append(errs, fmt.Sprintf("operationTimeouts.%s: %q must be one of create, read, update or delete", method, c.OperationTimeouts[method]))
+		}
+	}
+	return errs.err()
+}
+
+// merge returns c overridden by o. Injected fields, renames, custom types and timeouts are merged by key, exclusions
+// and JSON strings are combined. The version is overridden if o sets one, enum types are enabled if either enables them.
+func (c config) merge(o config) config {
+	merged := config{
+		InjectedFields: map[string]injectedField{},
+		Rename:         map[string]string{},
+		Exclude:        append(append([]string{}, c.Exclude...), o.Exclude...),
+		JSONStrings:    append(append([]string{}, c.JSONStrings...), o.JSONStrings...),
+		Timeouts:       c.Timeouts.merge(o.Timeouts),
+		Version:        c.Version,
+		EnumTypes:      c.EnumTypes || o.EnumTypes,
+	}
+	if o.Version != 0 {
+		merged.Version = o.Version
+	}
+	for _, cfg := range []config{c, o} {
+		for name, field := range cfg.InjectedFields {
+			merged.InjectedFields[name] = field
+		}
+		for field, name := range cfg.Rename {
+			merged.Rename[field] = name
+		}
+		for field, typ := range cfg.CustomTypes {
+			if merged.CustomTypes == nil {
+				merged.CustomTypes = map[string]string{}
+			}
+			merged.CustomTypes[field] = typ
+		}
+	}
+	return merged
+}
+
+func (c config) excluded(f *protogen.Field) bool {
+	for _, name := range c.Exclude {
+		if name == string(f.Desc.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonString returns whether the field is listed in jsonStrings.
+func (c config) jsonString(f *protogen.Field) bool {
+	for _, name := range c.JSONStrings {
+		if name == string(f.Desc.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+// attributeName returns the name of the attribute generated for the field.
+func (c config) attributeName(f *protogen.Field) string {
+	if name, ok := c.Rename[string(f.Desc.Name())]; ok {
+		return name
+	}
+	return snakeCase(f.GoName)
+}
+
+// setSource records where the config was loaded from against everything it injects.
+func (c config) setSource(source string) {
+	for name, field := range c.InjectedFields {
+		c.InjectedFields[name] = field.withSource(fmt.Sprintf("%s: injectedFields.%s", source, name))
+	}
+}
+
+// decodeConfig strictly decodes a config file into out, rejecting unknown keys.
+func decodeConfig(contents []byte, out interface{}) error {
+	dec := yaml.NewDecoder(bytes.NewReader(contents))
+	dec.KnownFields(true)
+	if err := dec.Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// parseConfig strictly decodes a message config file and validates its contents.
+func parseConfig(contents []byte) (config, error) {
+	cfg := config{}
+	if err := decodeConfig(contents, &cfg); err != nil {
+		return config{}, err
+	}
+	return cfg, cfg.validate()
+}
+
+// LoadConfig loads the project wide config. An empty config is returned if filename is empty.
+func LoadConfig(filename string) (*Config, error) {
+	cfg := &Config{}
+	if len(filename) == 0 {
+		return cfg, nil
+	}
+	contents, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %v", filename, err)
+	}
+	if err := decodeConfig(contents, cfg); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", filename, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, prefixErrors("invalid "+filename, err)
+	}
+	cfg.Defaults.setSource(filename + ": defaults")
+	for i, rule := range cfg.Messages {
+		rule.setSource(fmt.Sprintf("%s: messages[%d]", filename, i))
+	}
+	return cfg, nil
+}
+
+// messageConfig returns the config for a message. From lowest to highest precedence it is made up of
+// the project defaults, every matching project rule in the order they are declared and finally the
+// config referenced by the message's leading comments. It is resolved once per message, an invalid
+// comment config is reported by ConfigErrors and left out, so the message keeps its project config.
+func (c *Config) messageConfig(m *protogen.Message) config {
+	if cfg, ok := c.configs[m.Desc.FullName()]; ok {
+		return cfg
+	}
+	if c.configs == nil {
+		c.configs = map[protoreflect.FullName]config{}
+	}
+	cfg := c.Defaults
+	for _, rule := range c.Messages {
+		if matched, _ := path.Match(rule.Match, string(m.Desc.FullName())); matched {
+			cfg = cfg.merge(rule.config)
+		}
+	}
+	comment, err := loadConfig(m, c.protoPaths)
+	if err != nil {
+		c.configErrs.add(err)
+	} else {
+		cfg = cfg.merge(comment)
+	}
+	c.configs[m.Desc.FullName()] = cfg
+	return cfg
 }
 
-type injectedField struct {
-	// Has to be in format types.<Type> where type is one of these constants
-	// https://pkg.go.dev/github.com/hashicorp/terraform-plugin-framework@v0.14.0/types?utm_source=gopls#pkg-constants
-	Type     string `yaml:"type,omitempty"`
-	Required bool   `yaml:"required,omitempty"`
-	Computed bool   `yaml:"computed,omitempty"`
-	Optional bool   `yaml:"optional,omitempty"`
+// ConfigErrors returns the errors of every message config resolved so far, once each.
+func (c *Config) ConfigErrors() error {
+	return c.configErrs.err()
 }
 
-func loadConfig(m *protogen.Message) config {
+// loadConfig loads the config referenced by the message's leading comments, relative to the message's own source
+// file. The source file is relative to the proto path it was imported from, so each of protoPaths is tried in order,
+// or the directory protoc is run from if there are none. An empty config is returned if the message doesn't reference
+// one.
+func loadConfig(m *protogen.Message, protoPaths []string) (config, error) {
 	dir := path.Dir(m.Location.SourceFile)
 	filename := getFileName(m.Comments.Leading)
 	cfg := config{}
 	if len(filename) > 0 {
-		location := path.Join(dir, filename)
-		contents, err := ioutil.ReadFile(location)
+		if len(protoPaths) == 0 {
+			protoPaths = []string{"."}
+		}
+		var source string
+		var contents []byte
+		var err error
+		for _, protoPath := range protoPaths {
+			source = path.Join(protoPath, dir, filename)
+			if contents, err = ioutil.ReadFile(source); !errors.Is(err, fs.ErrNotExist) {
+				break
+			}
+		}
 		if err != nil {
-			panic(fmt.Sprintf("unable to read contents of '%s': %v", location, err))
+			return config{}, fmt.Errorf("%s: unable to read %s: %v", location(m.Desc), filename, err)
 		}
 
-		if err := yaml.Unmarshal(contents, &cfg); err != nil {
-			panic(fmt.Sprintf("unable to unmarshal contents of '%s': %v", location, err))
+		cfg, err = parseConfig(contents)
+		if err != nil {
+			return config{}, prefixErrors(fmt.Sprintf("%s: invalid %s", location(m.Desc), filename), err)
 		}
+		cfg.setSource(source)
 	}
-	return cfg
+	return cfg, nil
 }
 
 func getFileName(c protogen.Comments) string {
@@ -65,3 +340,12 @@ func getFileName(c protogen.Comments) string {
 	}
 	return match[0][1]
 }
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,267 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestParseConfig(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg, err := parseConfig([]byte(`
+injectedFields:
+  id:
+    type: types.StringType
+    computed: true
+    optional: true
+`))
+		require.NoError(t, err)
+		require.Equal(t, injectedField{typeSpec: typeSpec{Type: "types.StringType"}, Computed: true, Optional: true}, cfg.InjectedFields["id"])
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		_, err := parseConfig([]byte(""))
+		require.NoError(t, err)
+	})
+
+	t.Run("Unknown keys", func(t *testing.T) {
+		_, err := parseConfig([]byte(`
+injectFields: {}
+`))
+		require.ErrorContains(t, err, "field injectFields not found")
+
+		_, err = parseConfig([]byte(`
+injectedFields:
+  id:
+    type: types.StringType
+    requried: true
+`))
+		require.ErrorContains(t, err, "field requried not found")
+	})
+
+	t.Run("Timeouts", func(t *testing.T) {
+		cfg, err := parseConfig([]byte(`
+timeouts: {}
+`))
+		require.NoError(t, err)
+		require.Equal(t, &timeoutsConfig{}, cfg.Timeouts)
+
+		_, err = parseConfig([]byte(`
+timeouts:
+  create: 30m
+  delete: soon
+`))
+		require.Equal(t, schemaErrors{`timeouts.delete: "soon" is not a valid duration, e.g. 30s or 2h45m`}, err)
+	})
+
+	t.Run("Invalid fields", func(t *testing.T) {
+		_, err := parseConfig([]byte(`
+injectedFields:
+  a:
+    type: types.Strin
+    optional: true
+  b:
+    type: types.StringType
+  c:
+    type: types.StringType
+    required: true
+    computed: true
+`))
+		require.Equal(t, schemaErrors{
+			`injectedFields.a: unknown type "types.Strin", must be one of [bool float64 int64 number string types.BoolType types.Float64Type types.Int64Type types.NumberType types.StringType], list, map, set or object`,
+			"injectedFields.b: one of required, optional or computed must be set",
+			"injectedFields.c: required can't be combined with optional or computed",
+		}, err)
+	})
+}
+
+func TestConfigMerge(t *testing.T) {
+	defaults := config{
+		InjectedFields: map[string]injectedField{"a": {typeSpec: typeSpec{Type: "types.StringType"}}, "b": {typeSpec: typeSpec{Type: "types.StringType"}}},
+		Rename:         map[string]string{"Foo": "foo_defaults"},
+		Exclude:        []string{"Bar"},
+		Timeouts:       &timeoutsConfig{Create: "10m", Delete: "5m"},
+		JSONStrings:    []string{"spec"},
+	}
+	override := config{
+		InjectedFields: map[string]injectedField{"b": {typeSpec: typeSpec{Type: "types.BoolType"}}},
+		Rename:         map[string]string{"Foo": "foo_override"},
+		Exclude:        []string{"Baz"},
+		Timeouts:       &timeoutsConfig{Create: "1h"},
+		JSONStrings:    []string{"metadata"},
+	}
+	require.Equal(t, config{
+		InjectedFields: map[string]injectedField{"a": {typeSpec: typeSpec{Type: "types.StringType"}}, "b": {typeSpec: typeSpec{Type: "types.BoolType"}}},
+		Rename:         map[string]string{"Foo": "foo_override"},
+		Exclude:        []string{"Bar", "Baz"},
+		Timeouts:       &timeoutsConfig{Create: "1h", Delete: "5m"},
+		JSONStrings:    []string{"spec", "metadata"},
+	}, defaults.merge(override))
+	require.Nil(t, config{}.merge(config{}).Timeouts)
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("No file", func(t *testing.T) {
+		cfg, err := LoadConfig("")
+		require.NoError(t, err)
+		require.Equal(t, &Config{}, cfg)
+	})
+
+	t.Run("Test config", func(t *testing.T) {
+		cfg, err := LoadConfig("../../test/terraform.yaml")
+		require.NoError(t, err)
+		require.Equal(t, "test.Glob*", cfg.Messages[0].Match)
+		require.Equal(t, "../../test/terraform.yaml: messages[0]: injectedFields.precedence", cfg.Messages[0].InjectedFields["precedence"].source)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		contents := []byte(`
+messages:
+  - match: "mycorp.[v1"
+    rename:
+      Foo: Not-Valid
+`)
+		cfg := &Config{}
+		require.NoError(t, decodeConfig(contents, cfg))
+		require.Equal(t, schemaErrors{
+			`messages[0].match: "mycorp.[v1" is not a valid message name or pattern`,
+			`messages[0]: rename.Foo: "Not-Valid" is not a valid attribute name`,
+		}, cfg.validate())
+	})
+
+	t.Run("Invalid type mapping", func(t *testing.T) {
+		contents := []byte(`
+typeMappings:
+  google.type.Date:
+    type: list
+    toTerraform: DateToTerraform
+    fromTerraform: github.com/mycorp/conv.DateFromTerraform
+    sample: DateSample
+`)
+		cfg := &Config{}
+		require.NoError(t, decodeConfig(contents, cfg))
+		require.Equal(t, schemaErrors{
+			`typeMappings.google.type.Date: list type requires an elementType`,
+			`typeMappings.google.type.Date: toTerraform: "DateToTerraform" must be a fully qualified function, e.g. github.com/hashicorp/terraform-plugin-framework/resource.RequiresReplace`,
+			`typeMappings.google.type.Date: sample: "DateSample" must be a fully qualified function, e.g. github.com/hashicorp/terraform-plugin-framework/resource.RequiresReplace`,
+		}, cfg.validate())
+	})
+
+	t.Run("Invalid JSON message pattern", func(t *testing.T) {
+		cfg := &Config{JSONMessages: []string{"mycorp.v1.Settings", "mycorp.["}}
+		require.Equal(t, schemaErrors{
+			`jsonMessages[1]: "mycorp.[" is not a valid message name or pattern`,
+		}, cfg.validate())
+	})
+
+	t.Run("Invalid operation timeout", func(t *testing.T) {
+		cfg := &Config{OperationTimeouts: map[string]string{"mycorp.v1.Widgets.BatchCreateWidgets": "create", "mycorp.v1.Widgets.PurgeWidget": "purge"}}
+		require.Equal(t, schemaErrors{
+			`operationTimeouts.mycorp.v1.Widgets.PurgeWidget: "purge" must be one of create, read, update or delete`,
+		}, cfg.validate())
+	})
+
+	t.Run("Invalid custom type", func(t *testing.T) {
+		cfg := config{CustomTypes: map[string]string{"spec": "JSONType{}"}}
+		require.Equal(t, schemaErrors{
+			`customTypes.spec: "JSONType{}" must be a fully qualified type or variable, e.g. github.com/mycorp/tftypes.JSONType{}`,
+		}, cfg.validate())
+	})
+
+	t.Run("JSON string with a custom type", func(t *testing.T) {
+		cfg := config{CustomTypes: map[string]string{"spec": "github.com/mycorp/tftypes.JSONType{}"}, JSONStrings: []string{"labels", "spec"}}
+		require.Equal(t, schemaErrors{`jsonStrings[1]: spec also has a custom type`}, cfg.validate())
+	})
+}
+
+func TestMessageConfigProtoPaths(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "b", "other"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b", "other", "widget.yaml"), []byte("exclude:\n  - etag\n"), 0o644))
+
+	gen, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"other/widget.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{{
+			Name:        proto.String("other/widget.proto"),
+			Package:     proto.String("other"),
+			Syntax:      proto.String("proto3"),
+			Options:     &descriptorpb.FileOptions{GoPackage: proto.String("example.com/other")},
+			MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("Widget")}},
+			SourceCodeInfo: &descriptorpb.SourceCodeInfo{Location: []*descriptorpb.SourceCodeInfo_Location{
+				{Path: []int32{4, 0}, Span: []int32{0, 0, 0}, LeadingComments: proto.String(" +terraform-gen:config:widget.yaml\n")},
+			}},
+		}},
+	})
+	require.NoError(t, err)
+	m := gen.Files[0].Messages[0]
+
+	t.Run("Found in a later proto path", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.ProtoPaths([]string{filepath.Join(dir, "a"), filepath.Join(dir, "b")})
+		msgCfg := cfg.messageConfig(m)
+		require.NoError(t, cfg.ConfigErrors())
+		require.Equal(t, []string{"etag"}, msgCfg.Exclude)
+	})
+	t.Run("Not found", func(t *testing.T) {
+		cfg := &Config{
+			Defaults: config{Exclude: []string{"etag"}},
+			Messages: []messageRule{{Match: "other.*", config: config{Rename: map[string]string{"name": "title"}}}},
+		}
+		cfg.ProtoPaths([]string{filepath.Join(dir, "a")})
+		msgCfg := cfg.messageConfig(m)
+		require.ErrorContains(t, cfg.ConfigErrors(), "unable to read widget.yaml")
+		// Only the comment config is left out, the project config still applies.
+		require.Equal(t, []string{"etag"}, msgCfg.Exclude)
+		require.Equal(t, map[string]string{"name": "title"}, msgCfg.Rename)
+	})
+	t.Run("Read once", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.ProtoPaths([]string{filepath.Join(dir, "b")})
+		require.Equal(t, []string{"etag"}, cfg.messageConfig(m).Exclude)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "b", "other", "widget.yaml"), []byte("exclude:\n  - name\n"), 0o644))
+		require.Equal(t, []string{"etag"}, cfg.messageConfig(m).Exclude)
+	})
+}
+
+func TestConfigJSONSchemaTypes(t *testing.T) {
+	contents, err := ioutil.ReadFile("../../schema/config.schema.json")
+	require.NoError(t, err)
+
+	var schema struct {
+		Definitions struct {
+			TypeSpec struct {
+				Properties struct {
+					Type struct {
+						Enum []string `json:"enum"`
+					} `json:"type"`
+				} `json:"properties"`
+			} `json:"typeSpec"`
+		} `json:"definitions"`
+	}
+	require.NoError(t, json.Unmarshal(contents, &schema))
+	types := append(sortedKeys(primitiveTypes), sortedKeys(collectionTypes)...)
+	types = append(types, "object")
+	require.Equal(t, types, schema.Definitions.TypeSpec.Properties.Type.Enum)
+}
@@ -0,0 +1,142 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	j "github.com/dave/jennifer/jen"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Copy generates Copy<Message>FromTerraform and Copy<Message>ToTerraform functions that copy between a message and
+// the raw Terraform value of its schema, e.g. plan.Raw and state.Raw. Fields with presence, such as proto3 optional
+// and message fields, are null when they aren't set.
+func Copy(f *j.File, m *protogen.Message, cfg *Config) {
+	l := log.With().Str("generator", "Copy").Str("proto", m.GoIdent.GoName).Logger()
+	l.Debug().Msg("Generating copy functions")
+
+	copies := "copy" + m.GoIdent.GoName
+	f.Var().Id(copies).Op("=").Index().Qual(Runtime, "FieldCopy").Values(fieldCopies(l, cfg, m)...)
+
+	fromCall := j.Qual(Runtime, "CopyFromTerraform").Call(
+		j.Id("ctx"), j.Id("tf"), j.Id("obj").Dot("ProtoReflect").Call(), j.Id(copies),
+	)
+	toCall := j.Qual(Runtime, "CopyToTerraform").Call(
+		j.Id("ctx"), j.Id("obj").Dot("ProtoReflect").Call(), j.Id("tf"), j.Id(copies),
+	)
+	fromBody, toBody := []j.Code{j.Return(fromCall)}, []j.Code{j.Return(toCall)}
+	if p, err := resourceNamePattern(m); p != nil && err == nil {
+		if nameField := resourceNameField(m); nameField != nil {
+			fields := segmentFields(cfg.messageConfig(m), m, p)
+			fromBody, toBody = resourceNameCopies(m, p, nameField, fields, fromCall, toCall)
+		}
+	}
+
+	from := "Copy" + m.GoIdent.GoName + "FromTerraform"
+	f.Commentf("// %v copies the Terraform value of a %v, e.g. plan.Raw, into obj\n", from, m.GoIdent.GoName).
+		Func().Id(from).
+		Params(
+			j.Id("ctx").Qual("context", "Context"),
+			j.Id("tf").Qual(TFTypes, "Value"),
+			j.Id("obj").Op("*").Id(m.GoIdent.GoName),
+		).
+		Qual(Diag, "Diagnostics").
+		Block(fromBody...)
+
+	to := "Copy" + m.GoIdent.GoName + "ToTerraform"
+	f.Commentf("// %v copies obj into the Terraform value of a %v, e.g. state.Raw\n", to, m.GoIdent.GoName).
+		Func().Id(to).
+		Params(
+			j.Id("ctx").Qual("context", "Context"),
+			j.Id("obj").Op("*").Id(m.GoIdent.GoName),
+			j.Id("tf").Op("*").Qual(TFTypes, "Value"),
+		).
+		Qual(Diag, "Diagnostics").
+		Block(toBody...)
+}
+
+// resourceNameCopies extends the copy functions of a resource message. Copying from Terraform builds the name field
+// from the name segment attributes when they are all set, e.g. in a Create plan. Copying to Terraform parses the name
+// field into the id and name segment attributes, except segments that are fields, which are copied like other fields.
+func resourceNameCopies(m *protogen.Message, p *resourcePattern, nameField *protogen.Field, fields map[string]*protogen.Field, fromCall, toCall *j.Statement) ([]j.Code, []j.Code) {
+	typ := resourceNameType(m)
+	name := j.Id("obj").Dot(nameField.GoName)
+	variables := []j.Code{}
+	segments := j.Dict{}
+	attrs := j.Dict{j.Lit("id"): name.Clone()}
+	for _, v := range p.variables {
+		variables = append(variables, j.Lit(v))
+		segments[j.Id(goName(v))] = j.Id("values").Index(j.Lit(v))
+		if fields[v] == nil {
+			attrs[j.Lit(v)] = j.Id("name").Dot(goName(v))
+		}
+	}
+
+	fromBody := []j.Code{
+		j.Id("diags").Op(":=").Add(fromCall),
+		j.If(
+			j.List(j.Id("values"), j.Id("ok")).Op(":=").Qual(Runtime, "ResourceNameSegments").Call(append([]j.Code{j.Id("tf")}, variables...)...),
+			j.Id("ok"),
+		).Block(name.Clone().Op("=").Id(typ).Values(segments).Dot("String").Call()),
+		j.Return(j.Id("diags")),
+	}
+	toBody := []j.Code{
+		j.Id("diags").Op(":=").Add(toCall),
+		j.If(name.Clone().Op("==").Lit("").Op("||").Id("diags").Dot("HasError").Call()).Block(j.Return(j.Id("diags"))),
+		j.List(j.Id("name"), j.Err()).Op(":=").Id("Parse" + typ).Call(name.Clone()),
+		j.If(j.Err().Op("!=").Nil()).Block(
+			j.Id("diags").Dot("AddAttributeError").Call(j.Qual(Path, "Root").Call(j.Lit("id")), j.Lit("Invalid resource name"), j.Err().Dot("Error").Call()),
+			j.Return(j.Id("diags")),
+		),
+		j.If(
+			j.Err().Op(":=").Qual(Runtime, "SetStringAttributes").Call(j.Id("tf"), j.Map(j.String()).String().Values(attrs)),
+			j.Err().Op("!=").Nil(),
+		).Block(j.Id("diags").Dot("AddError").Call(j.Lit("Unable to copy to Terraform"), j.Err().Dot("Error").Call())),
+		j.Return(j.Id("diags")),
+	}
+	return fromBody, toBody
+}
+
+// fieldCopies maps each field to its attribute, using the same attribute names as the schema.
+func fieldCopies(l zerolog.Logger, cfg *Config, m *protogen.Message) []j.Code {
+	msgCfg := cfg.messageConfig(m)
+	copies := []j.Code{}
+	for _, f := range m.Fields {
+		if msgCfg.excluded(f) {
+			continue
+		}
+		d := j.Dict{
+			j.Id("Attribute"): j.Lit(msgCfg.attributeName(f)),
+			j.Id("Field"):     j.Lit(string(f.Desc.Name())),
+		}
+		nested := f.Message
+		if f.Desc.IsMap() {
+			nested = f.Message.Fields[1].Message
+		}
+		if msgCfg.jsonString(f) {
+			d[j.Id("Mapping")] = j.Qual(Runtime, "JSONStringMapping")
+		} else if mapping, ok := cfg.typeMapping(nested); ok {
+			d[j.Id("Mapping")] = mapping.code()
+		} else if nested != nil {
+			if children := fieldCopies(l, cfg, nested); len(children) > 0 {
+				d[j.Id("Attributes")] = j.Index().Qual(Runtime, "FieldCopy").Values(children...)
+			}
+		}
+		copies = append(copies, j.Values(d))
+	}
+	return copies
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	j "github.com/dave/jennifer/jen"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// customType splits a fully qualified custom type into its import path and name. Types ending in {} are rendered as
+// composite literals, anything else as a package level variable.
+func customType(typ string) (string, string, bool) {
+	return goCall{Func: strings.TrimSuffix(typ, "{}")}.split()
+}
+
+func customTypeCode(typ string) *j.Statement {
+	pkg, name, _ := customType(typ)
+	if strings.HasSuffix(typ, "{}") {
+		return j.Qual(pkg, name).Values()
+	}
+	return j.Qual(pkg, name)
+}
+
+// setCustomType applies the field's custom type, a JSON string type or, if enum types are enabled, an enum type to its
+// attribute. Custom and JSON string types replace the attribute's whole type, enum types the type of the enum values,
+// which become strings set to the enum value's name.
+func setCustomType(msgCfg config, f *protogen.Field, attr *attribute) error {
+	if typ, ok := msgCfg.CustomTypes[string(f.Desc.Name())]; ok {
+		if attr.Type == nil {
+			return fmt.Errorf("%s: custom type %s can only be used for typed attributes, not nested attributes", location(f.Desc), typ)
+		}
+		attr.Type.custom = customTypeCode(typ)
+		return nil
+	}
+	if msgCfg.jsonString(f) {
+		if f.Desc.Kind() != protoreflect.StringKind || f.Desc.IsList() || f.Desc.IsMap() {
+			return fmt.Errorf("%s: jsonStrings field %s must be a singular string field", location(f.Desc), f.Desc.Name())
+		}
+		attr.Type.custom = j.Qual(Runtime, "JSONStringType").Values()
+		return nil
+	}
+	enum := f.Desc.Enum()
+	if f.Desc.IsMap() {
+		enum = f.Desc.MapValue().Enum()
+	}
+	if !msgCfg.EnumTypes || enum == nil || attr.Type == nil {
+		return nil
+	}
+	values := []j.Code{}
+	for i := 0; i < enum.Values().Len(); i++ {
+		values = append(values, j.Lit(string(enum.Values().Get(i).Name())))
+	}
+	enumType := j.Qual(Runtime, "EnumType").Values(j.Dict{
+		j.Id("Name"):   j.Lit(string(enum.FullName())),
+		j.Id("Values"): j.Index().String().Values(values...),
+	})
+	typ := attr.Type
+	if typ.ElemType != nil {
+		typ = typ.ElemType
+	}
+	typ.Kind = "string"
+	typ.custom = enumType
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Examples returns an example configuration of every resource message in the file, as resources of provider, keyed by
+// the path tfplugindocs reads them from relative to dir, <dir>/resources/<resource type>/resource.tf. Every attribute
+// that can be set is populated with a placeholder, required attributes first and optional ones commented out.
+func Examples(file *protogen.File, cfg *Config, provider, dir string) (map[string][]byte, error) {
+	examples := map[string][]byte{}
+	errs := schemaErrors{}
+	for _, m := range cfg.GeneratedMessages(file) {
+		if !cfg.resource(m) {
+			continue
+		}
+		l := log.With().Str("generator", "Examples").Str("proto", m.GoIdent.GoName).Logger()
+		l.Debug().Msg("Generating example")
+		attrs, err := schemaAttributes(l, cfg, m)
+		if err != nil {
+			errs.add(err)
+			continue
+		}
+		name := resourceTypeName(provider, m)
+		lines := []string{fmt.Sprintf("resource %q \"example\" {", name)}
+		lines = append(lines, exampleAttributes(attrs, "  ")...)
+		lines = append(lines, "}")
+		examples[path.Join(dir, "resources", name, "resource.tf")] = []byte(strings.Join(lines, "\n") + "\n")
+	}
+	return examples, errs.err()
+}
+
+// exampleAttributes returns a line per attribute that can be set, required attributes first and optional ones commented
+// out. Consecutive single line attributes have their = aligned like terraform fmt does.
+func exampleAttributes(attrs map[string]*attribute, indent string) []string {
+	required, optional := []string{}, []string{}
+	for _, name := range sortedKeys(attrs) {
+		switch attr := attrs[name]; {
+		case attr.Required:
+			required = append(required, name)
+		case attr.Optional:
+			optional = append(optional, name)
+		}
+	}
+
+	lines := []string{}
+	for i, group := range [][]string{required, optional} {
+		if len(group) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		prefix := indent
+		if i == 1 {
+			prefix += "# "
+		}
+
+		// Values are rendered first so single line attributes can be aligned.
+		values := make([][]string, len(group))
+		for j, name := range group {
+			values[j] = exampleValue(attrs[name], indent)
+		}
+		for j := 0; j < len(group); {
+			if len(values[j]) > 1 {
+				lines = append(lines, prefix+group[j]+" = "+values[j][0])
+				for _, line := range values[j][1:] {
+					lines = append(lines, strings.TrimRight(prefix+strings.TrimPrefix(line, indent), " "))
+				}
+				j++
+				continue
+			}
+			end, width := j, 0
+			for ; end < len(group) && len(values[end]) == 1; end++ {
+				if len(group[end]) > width {
+					width = len(group[end])
+				}
+			}
+			for ; j < end; j++ {
+				lines = append(lines, fmt.Sprintf("%s%-*s = %s", prefix, width, group[j], values[j][0]))
+			}
+		}
+	}
+	return lines
+}
+
+// exampleValue returns the lines of a placeholder value for the attribute, continuation lines start with indent.
+func exampleValue(attr *attribute, indent string) []string {
+	if attr.example != "" {
+		return []string{attr.example}
+	}
+	if attr.Attributes == nil {
+		return strings.Split(exampleTypeValue(attr, attr.Type, indent), "\n")
+	}
+	inner := indent + "  "
+	object := func(indent, inner string) []string {
+		attrs := exampleAttributes(attr.Attributes, inner)
+		if len(attrs) == 0 {
+			return []string{"{}"}
+		}
+		lines := append([]string{"{"}, attrs...)
+		return append(lines, indent+"}")
+	}
+	switch attr.Nesting {
+	case "list", "set":
+		lines := object(indent, inner)
+		lines[0] = "[" + lines[0]
+		lines[len(lines)-1] += "]"
+		return lines
+	case "map":
+		entry := object(inner, inner+"  ")
+		lines := []string{"{", inner + "key = " + entry[0]}
+		lines = append(lines, entry[1:]...)
+		return append(lines, indent+"}")
+	}
+	return object(indent, inner)
+}
+
+// exampleTypeValue returns a placeholder for the type, enums use their first value after the zero value.
+func exampleTypeValue(attr *attribute, t *attributeType, indent string) string {
+	if t == nil {
+		return "null"
+	}
+	inner := indent + "  "
+	switch t.Kind {
+	case "list", "set":
+		return "[" + exampleTypeValue(nil, t.ElemType, indent) + "]"
+	case "map":
+		return "{\n" + inner + "key = " + exampleTypeValue(nil, t.ElemType, inner) + "\n" + indent + "}"
+	case "object":
+		if len(t.AttrTypes) == 0 {
+			return "{}"
+		}
+		lines := []string{"{"}
+		for _, name := range sortedKeys(t.AttrTypes) {
+			lines = append(lines, inner+name+" = "+exampleTypeValue(nil, t.AttrTypes[name], inner))
+		}
+		return strings.Join(append(lines, indent+"}"), "\n")
+	case "bool":
+		return "true"
+	case "float64", "number":
+		return "1.5"
+	case "int64":
+		if value := exampleEnumValue(attr); value != nil {
+			return fmt.Sprintf("%d # %s", value.Number(), value.Name())
+		}
+		return "1"
+	}
+	if value := exampleEnumValue(attr); value != nil {
+		return fmt.Sprintf("%q", value.Name())
+	}
+	return `"example"`
+}
+
+func exampleEnumValue(attr *attribute) protoreflect.EnumValueDescriptor {
+	if attr == nil || attr.enum == nil || attr.enum.Values().Len() == 0 {
+		return nil
+	}
+	values := attr.enum.Values()
+	if values.Len() > 1 && values.Get(0).Number() == 0 {
+		return values.Get(1)
+	}
+	return values.Get(0)
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExampleAttributes(t *testing.T) {
+	str := &attributeType{Kind: "string"}
+	t.Run("required first and optional commented", func(t *testing.T) {
+		attrs := map[string]*attribute{
+			"name":      {Required: true, Type: str},
+			"count":     {Required: true, Type: &attributeType{Kind: "int64"}},
+			"labels":    {Optional: true, Type: &attributeType{Kind: "map", ElemType: str}},
+			"enabled":   {Optional: true, Type: &attributeType{Kind: "bool"}},
+			"id":        {Computed: true, Type: str},
+			"ratio":     {Optional: true, Type: &attributeType{Kind: "float64"}},
+			"duration":  {Optional: true, Type: str, example: `"30m"`},
+			"tags_list": {Optional: true, Type: &attributeType{Kind: "list", ElemType: str}},
+		}
+		require.Equal(t, []string{
+			`  count = 1`,
+			`  name  = "example"`,
+			``,
+			`  # duration = "30m"`,
+			`  # enabled  = true`,
+			`  # labels = {`,
+			`  #   key = "example"`,
+			`  # }`,
+			`  # ratio     = 1.5`,
+			`  # tags_list = ["example"]`,
+		}, exampleAttributes(attrs, "  "))
+	})
+	t.Run("nested attributes", func(t *testing.T) {
+		nested := map[string]*attribute{"a": {Required: true, Type: str}}
+		attrs := map[string]*attribute{
+			"list":  {Required: true, Nesting: "list", Attributes: nested},
+			"map":   {Required: true, Nesting: "map", Attributes: nested},
+			"empty": {Required: true, Nesting: "single", Attributes: map[string]*attribute{"id": {Computed: true, Type: str}}},
+		}
+		require.Equal(t, []string{
+			`  empty = {}`,
+			`  list = [{`,
+			`    a = "example"`,
+			`  }]`,
+			`  map = {`,
+			`    key = {`,
+			`      a = "example"`,
+			`    }`,
+			`  }`,
+		}, exampleAttributes(attrs, "  "))
+	})
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	j "github.com/dave/jennifer/jen"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/rs/zerolog/log"
+)
+
+// indexHelpers records the messages that get a nested attributes helper: those used as nested attributes by a
+// generated message, whose own file is being generated. Helpers are unexported attrs<Message> functions, unless a
+// message in another Go package uses them, which get an exported GenAttributes<Message> function instead.
+func (c *Config) indexHelpers(files []*protogen.File) {
+	c.helpers = map[protoreflect.FullName]protogen.GoIdent{}
+	helpers := map[protoreflect.FullName]*protogen.Message{}
+	exported := map[protoreflect.FullName]bool{}
+	generated := map[string]bool{}
+	for _, file := range files {
+		if file.Generate {
+			generated[file.Desc.Path()] = true
+		}
+	}
+
+	visited := map[protoreflect.FullName]bool{}
+	var visit func(m *protogen.Message)
+	visit = func(m *protogen.Message) {
+		if visited[m.Desc.FullName()] {
+			return
+		}
+		visited[m.Desc.FullName()] = true
+		msgCfg := c.messageConfig(m)
+		for _, f := range m.Fields {
+			nested := f.Message
+			if f.Desc.IsMap() {
+				nested = f.Message.Fields[1].Message
+			}
+			// Messages with a type mapping, including JSON messages, are a typed attribute.
+			if nested == nil || msgCfg.excluded(f) || c.mappedType(nested) != nil {
+				continue
+			}
+			if generated[nested.Location.SourceFile] {
+				helpers[nested.Desc.FullName()] = nested
+				if nested.GoIdent.GoImportPath != m.GoIdent.GoImportPath {
+					exported[nested.Desc.FullName()] = true
+				}
+			}
+			visit(nested)
+		}
+	}
+	for _, file := range files {
+		if !file.Generate {
+			continue
+		}
+		for _, m := range c.GeneratedMessages(file) {
+			visit(m)
+		}
+	}
+	for name, m := range helpers {
+		if exported[name] {
+			c.helpers[name] = m.GoIdent.GoImportPath.Ident("GenAttributes" + m.GoIdent.GoName)
+		} else {
+			c.helpers[name] = m.GoIdent.GoImportPath.Ident("attrs" + m.GoIdent.GoName)
+		}
+	}
+}
+
+// useHelpers renders nested attributes with their helper instead of inlining them, if the message has one. Helpers in
+// other Go packages are imported.
+func (c *Config) useHelpers(attrs map[string]*attribute) {
+	for _, attr := range attrs {
+		if m := attr.message; m != nil {
+			if helper, ok := c.helpers[m.Desc.FullName()]; ok {
+				attr.helper = helper
+				continue
+			}
+		}
+		c.useHelpers(attr.Attributes)
+	}
+}
+
+// AttributeHelpers generates an attrs<Message> or GenAttributes<Message> function for every message in the file that
+// is used as nested attributes, so each message's attributes are rendered once rather than everywhere it is used.
+func AttributeHelpers(f *j.File, file *protogen.File, cfg *Config) {
+	var generate func(messages []*protogen.Message)
+	generate = func(messages []*protogen.Message) {
+		for _, m := range messages {
+			generate(m.Messages)
+			helper, ok := cfg.helpers[m.Desc.FullName()]
+			if !ok {
+				continue
+			}
+			l := log.With().Str("generator", "AttributeHelpers").Str("proto", m.GoIdent.GoName).Logger()
+			l.Debug().Msg("Generating attributes helper")
+			attrs, err := fieldsAttributes(l, cfg, m, false)
+			if err != nil {
+				continue
+			}
+			cfg.useHelpers(attrs)
+
+			id := helper.GoName
+			f.Commentf("// %v returns the nested attributes of %v\n", id, m.GoIdent.GoName).
+				Func().Id(id).Params().
+				Map(j.String()).Qual(SDK, "Attribute").
+				Block(j.Return(attributesCode(attrs)))
+		}
+	}
+	generate(file.Messages)
+}
@@ -23,6 +23,18 @@ const (
 	Diag = "github.com/hashicorp/terraform-plugin-framework/diag"
 	// Attr represents the name of Terraform attr package
 	Attr = "github.com/hashicorp/terraform-plugin-framework/attr"
+	// Path represents the path to Terraform path package
+	Path = "github.com/hashicorp/terraform-plugin-framework/path"
+	// Resource represents the path to Terraform resource package
+	Resource = "github.com/hashicorp/terraform-plugin-framework/resource"
 	// TFTypes represents the name of Terraform SDK TFTypes package
 	TFTypes = "github.com/hashicorp/terraform-plugin-go/tftypes"
+	// FieldMask represents the path to the well known FieldMask package
+	FieldMask = "google.golang.org/protobuf/types/known/fieldmaskpb"
+	// Proto represents the path to the protobuf proto package
+	Proto = "google.golang.org/protobuf/proto"
+	// LongRunning represents the path to the google.longrunning package
+	LongRunning = "google.golang.org/genproto/googleapis/longrunning"
+	// Runtime represents the path to the helpers used by generated code
+	Runtime = "github.com/liamawhite/protoc-gen-terraform/pkg/runtime"
 )
@@ -0,0 +1,71 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	j "github.com/dave/jennifer/jen"
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ImportState generates an ImportState<Message> function for messages annotated with google.api.resource.
+// It has the same signature as resource.ResourceWithImportState's ImportState so resources can delegate to it.
+// Invalid patterns are reported by Scheme.
+func ImportState(f *j.File, m *protogen.Message) {
+	p, err := resourceNamePattern(m)
+	if p == nil || err != nil {
+		return
+	}
+	l := log.With().Str("generator", "ImportState").Str("proto", m.GoIdent.GoName).Logger()
+	l.Debug().Msg("Generating import state")
+
+	id := "ImportState" + m.GoIdent.GoName
+	typ := resourceNameType(m)
+
+	values := j.Dict{}
+	for _, v := range p.variables {
+		values[j.Id(goName(v))] = j.Id("values").Index(j.Lit(v))
+	}
+	body := []j.Code{
+		j.List(j.Id("values"), j.Err()).Op(":=").Qual(Runtime, "ParseImportID").Call(j.Lit(p.pattern), j.Id("req").Dot("ID")),
+		j.If(j.Err().Op("!=").Nil()).Block(
+			j.Id("resp").Dot("Diagnostics").Dot("AddError").Call(j.Lit("Invalid import ID"), j.Err().Dot("Error").Call()),
+			j.Return(),
+		),
+		j.Id("name").Op(":=").Id(typ).Values(values),
+		setImportedAttribute("id", j.Id("name").Dot("String").Call()),
+	}
+	for _, v := range p.variables {
+		body = append(body, setImportedAttribute(v, j.Id("name").Dot(goName(v))))
+	}
+
+	f.Commentf("// %v imports a %v from either its full resource name, %v, or just the name's variables separated by /.\n"+
+		"// Only the id and name segment attributes are set, the rest of the state is populated by Read.\n",
+		id, m.GoIdent.GoName, p.pattern).
+		Func().Id(id).
+		Params(
+			j.Id("ctx").Qual("context", "Context"),
+			j.Id("req").Qual(Resource, "ImportStateRequest"),
+			j.Id("resp").Op("*").Qual(Resource, "ImportStateResponse"),
+		).
+		Block(body...)
+}
+
+func setImportedAttribute(name string, value j.Code) j.Code {
+	return j.Id("resp").Dot("Diagnostics").Dot("Append").Call(
+		j.Id("resp").Dot("State").Dot("SetAttribute").Call(j.Id("ctx"), j.Qual(Path, "Root").Call(j.Lit(name)), value).Op("..."),
+	)
+}
@@ -0,0 +1,309 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	j "github.com/dave/jennifer/jen"
+	"github.com/rs/zerolog"
+)
+
+type injectedField struct {
+	typeSpec `yaml:",inline"`
+
+	// Attributes makes the field a nested attribute rather than a typed one, Nesting controls how they are nested.
+	Attributes map[string]injectedField `yaml:"attributes,omitempty"`
+	Nesting    string                   `yaml:"nesting,omitempty"`
+
+	Description string `yaml:"description,omitempty"`
+	Required    bool   `yaml:"required,omitempty"`
+	Computed    bool   `yaml:"computed,omitempty"`
+	Optional    bool   `yaml:"optional,omitempty"`
+	Sensitive   bool   `yaml:"sensitive,omitempty"`
+
+	// Default is used when the attribute isn't set in the config. Only primitive types can have a default.
+	Default interface{} `yaml:"default,omitempty"`
+
+	Validators    []goCall `yaml:"validators,omitempty"`
+	PlanModifiers []goCall `yaml:"planModifiers,omitempty"`
+
+	// source is where the field was injected from, used when reporting errors.
+	source string
+}
+
+// typeSpec is a Terraform type declared in config, e.g. a list of strings is
+//
+//	type: list
+//	elementType:
+//	  type: string
+type typeSpec struct {
+	Type           string              `yaml:"type,omitempty"`
+	ElementType    *typeSpec           `yaml:"elementType,omitempty"`
+	AttributeTypes map[string]typeSpec `yaml:"attributeTypes,omitempty"`
+}
+
+// goCall is a call to a function in another package, used to declare validators and plan modifiers.
+type goCall struct {
+	// Func is the fully qualified function, e.g. github.com/hashicorp/terraform-plugin-framework/resource.RequiresReplace
+	Func string        `yaml:"func"`
+	Args []interface{} `yaml:"args,omitempty"`
+}
+
+// primitiveTypes maps the primitive type names that can be used in config to framework types.
+// The types.<Type> form is kept for backwards compatibility.
+// Keep in sync with schema/config.schema.json.
+var primitiveTypes = map[string]string{
+	"bool":              "BoolType",
+	"float64":           "Float64Type",
+	"int64":             "Int64Type",
+	"number":            "NumberType",
+	"string":            "StringType",
+	"types.BoolType":    "BoolType",
+	"types.Float64Type": "Float64Type",
+	"types.Int64Type":   "Int64Type",
+	"types.NumberType":  "NumberType",
+	"types.StringType":  "StringType",
+}
+
+// collectionTypes maps collection type names to framework types, these all require an elementType.
+var collectionTypes = map[string]string{
+	"list": "ListType",
+	"map":  "MapType",
+	"set":  "SetType",
+}
+
+// defaultValueTypes maps primitive framework types to the value they use for defaults.
+var defaultValueTypes = map[string]string{
+	"BoolType":    "Bool",
+	"Float64Type": "Float64",
+	"Int64Type":   "Int64",
+	"StringType":  "String",
+}
+
+// nestingModes are the ways nested injected attributes can be nested.
+var nestingModes = map[string]string{
+	"":       "single",
+	"single": "single",
+	"list":   "list",
+	"map":    "map",
+	"set":    "set",
+}
+
+func (t typeSpec) validate() error {
+	errs := schemaErrors{}
+	switch {
+	case primitiveTypes[t.Type] != "":
+		if t.ElementType != nil || t.AttributeTypes != nil {
+			errs = append(errs, fmt.Sprintf("primitive type %q can't have an elementType or attributeTypes", t.Type))
+		}
+	case collectionTypes[t.Type] != "":
+		if t.ElementType == nil {
+			errs = append(errs, fmt.Sprintf("%s type requires an elementType", t.Type))
+			break
+		}
+		errs.add(prefixErrors("elementType", t.ElementType.validate()))
+	case t.Type == "object":
+		if len(t.AttributeTypes) == 0 {
+			errs = append(errs, "object type requires attributeTypes")
+		}
+		for _, name := range sortedKeys(t.AttributeTypes) {
+			errs.add(prefixErrors("attributeTypes."+name, t.AttributeTypes[name].validate()))
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("unknown type %q, must be one of %v, list, map, set or object", t.Type, sortedKeys(primitiveTypes)))
+	}
+	return errs.err()
+}
+
+// attributeType returns the attribute type for the spec.
+func (t typeSpec) attributeType() *attributeType {
+	if typ, ok := primitiveTypes[t.Type]; ok {
+		return &attributeType{Kind: strings.ToLower(strings.TrimSuffix(typ, "Type"))}
+	}
+	if _, ok := collectionTypes[t.Type]; ok {
+		return &attributeType{Kind: t.Type, ElemType: t.ElementType.attributeType()}
+	}
+	attrTypes := map[string]*attributeType{}
+	for name, spec := range t.AttributeTypes {
+		attrTypes[name] = spec.attributeType()
+	}
+	return &attributeType{Kind: "object", AttrTypes: attrTypes}
+}
+
+func (c goCall) validate() error {
+	if _, _, ok := c.split(); !ok {
+		return fmt.Errorf("%q must be a fully qualified function, e.g. github.com/hashicorp/terraform-plugin-framework/resource.RequiresReplace", c.Func)
+	}
+	for _, arg := range c.Args {
+		switch arg.(type) {
+		case string, int, float64, bool:
+		default:
+			return fmt.Errorf("%q has an unsupported argument %v, only strings, numbers and booleans are supported", c.Func, arg)
+		}
+	}
+	return nil
+}
+
+// split returns the import path and function name.
+func (c goCall) split() (string, string, bool) {
+	i := strings.LastIndex(c.Func, ".")
+	if i <= 0 || i < strings.LastIndex(c.Func, "/") || i == len(c.Func)-1 {
+		return "", "", false
+	}
+	return c.Func[:i], c.Func[i+1:], true
+}
+
+func (c goCall) code() *j.Statement {
+	pkg, fn, _ := c.split()
+	args := make([]j.Code, 0, len(c.Args))
+	for _, arg := range c.Args {
+		args = append(args, j.Lit(arg))
+	}
+	return j.Qual(pkg, fn).Call(args...)
+}
+
+func (f injectedField) validate() error {
+	errs := schemaErrors{}
+	if len(f.Attributes) > 0 {
+		if f.Type != "" {
+			errs = append(errs, "type can't be combined with attributes")
+		}
+		if _, ok := nestingModes[f.Nesting]; !ok {
+			errs = append(errs, fmt.Sprintf("unknown nesting %q, must be one of single, list, map or set", f.Nesting))
+		}
+		for _, name := range sortedKeys(f.Attributes) {
+			errs.add(prefixErrors("attributes."+name, f.Attributes[name].validate()))
+		}
+	} else {
+		if f.Nesting != "" {
+			errs = append(errs, "nesting requires attributes")
+		}
+		errs.add(f.typeSpec.validate())
+	}
+
+	// Terraform only allows computed to be combined with optional.
+	switch {
+	case f.Required && (f.Optional || f.Computed):
+		errs = append(errs, "required can't be combined with optional or computed")
+	case !f.Required && !f.Optional && !f.Computed:
+		errs = append(errs, "one of required, optional or computed must be set")
+	}
+
+	if f.Default != nil {
+		if _, ok := defaultValueTypes[primitiveTypes[f.Type]]; !ok {
+			errs = append(errs, "default is only supported for bool, float64, int64 and string types")
+		} else if err := f.validateDefault(); err != nil {
+			errs = append(errs, err.Error())
+		}
+		// The default is applied during planning so the provider has to be allowed to set it.
+		if !f.Optional || !f.Computed {
+			errs = append(errs, "default requires both optional and computed")
+		}
+	}
+
+	for i, v := range f.Validators {
+		errs.add(prefixErrors(fmt.Sprintf("validators[%d]", i), v.validate()))
+	}
+	for i, m := range f.PlanModifiers {
+		errs.add(prefixErrors(fmt.Sprintf("planModifiers[%d]", i), m.validate()))
+	}
+	return errs.err()
+}
+
+func (f injectedField) validateDefault() error {
+	var ok bool
+	switch primitiveTypes[f.Type] {
+	case "BoolType":
+		_, ok = f.Default.(bool)
+	case "Float64Type":
+		switch f.Default.(type) {
+		case int, float64:
+			ok = true
+		}
+	case "Int64Type":
+		_, ok = f.Default.(int)
+	case "StringType":
+		_, ok = f.Default.(string)
+	}
+	if !ok {
+		return fmt.Errorf("default %v is not a valid %s", f.Default, f.Type)
+	}
+	return nil
+}
+
+// withSource records where the field, and any nested attributes, were injected from.
+func (f injectedField) withSource(source string) injectedField {
+	f.source = source
+	if f.Attributes != nil {
+		attrs := make(map[string]injectedField, len(f.Attributes))
+		for name, attr := range f.Attributes {
+			attrs[name] = attr.withSource(fmt.Sprintf("%s.attributes.%s", source, name))
+		}
+		f.Attributes = attrs
+	}
+	return f
+}
+
+func injectedAttribute(l zerolog.Logger, f injectedField) (*attribute, error) {
+	attr := &attribute{
+		Description: f.Description,
+		Required:    f.Required,
+		Computed:    f.Computed,
+		Optional:    f.Optional,
+		Sensitive:   f.Sensitive,
+	}
+
+	if len(f.Attributes) > 0 {
+		attrs, err := injectedAttributes(l, f)
+		if err != nil {
+			return nil, err
+		}
+		attr.Nesting = nestingModes[f.Nesting]
+		attr.Attributes = attrs
+	} else {
+		attr.Type = f.typeSpec.attributeType()
+	}
+
+	for _, v := range f.Validators {
+		attr.validators = append(attr.validators, v.code())
+	}
+	if f.Default != nil {
+		value := defaultValueTypes[primitiveTypes[f.Type]]
+		attr.planModifiers = append(attr.planModifiers, j.Qual(Runtime, "DefaultValue").Call(
+			j.Qual(Types, value).Values(j.Dict{j.Id("Value"): j.Lit(f.Default)}),
+		))
+	}
+	for _, m := range f.PlanModifiers {
+		attr.planModifiers = append(attr.planModifiers, m.code())
+	}
+	return attr, nil
+}
+
+func injectedAttributes(l zerolog.Logger, f injectedField) (map[string]*attribute, error) {
+	attrs := map[string]*attribute{}
+	names := newAttributeSet(f.source)
+	errs := schemaErrors{}
+	for _, key := range sortedKeys(f.Attributes) {
+		name := snakeCase(key)
+		names.add(name, f.Attributes[key].source)
+		attr, err := injectedAttribute(l, f.Attributes[key])
+		errs.add(err)
+		attrs[name] = attr
+	}
+	errs.add(names.validate(false))
+	return attrs, errs.err()
+}
@@ -0,0 +1,105 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjectedFieldValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		errs schemaErrors
+	}{
+		{
+			name: "Collections require an element type",
+			yaml: `
+type: list
+optional: true
+`,
+			errs: schemaErrors{"list type requires an elementType"},
+		},
+		{
+			name: "Nested element types are validated",
+			yaml: `
+type: map
+elementType:
+  type: object
+optional: true
+`,
+			errs: schemaErrors{"elementType: object type requires attributeTypes"},
+		},
+		{
+			name: "Type can't be combined with attributes",
+			yaml: `
+type: string
+nesting: sideways
+attributes:
+  a:
+    type: string
+optional: true
+`,
+			errs: schemaErrors{
+				"type can't be combined with attributes",
+				`unknown nesting "sideways", must be one of single, list, map or set`,
+				"attributes.a: one of required, optional or computed must be set",
+			},
+		},
+		{
+			name: "Defaults",
+			yaml: `
+type: int64
+optional: true
+default: abc
+`,
+			errs: schemaErrors{
+				"default abc is not a valid int64",
+				"default requires both optional and computed",
+			},
+		},
+		{
+			name: "Function references",
+			yaml: `
+type: string
+optional: true
+validators:
+  - func: LengthAtLeast
+planModifiers:
+  - func: github.com/hashicorp/terraform-plugin-framework/resource.RequiresReplace
+    args: [[1]]
+`,
+			errs: schemaErrors{
+				`validators[0]: "LengthAtLeast" must be a fully qualified function, e.g. github.com/hashicorp/terraform-plugin-framework/resource.RequiresReplace`,
+				`planModifiers[0]: "github.com/hashicorp/terraform-plugin-framework/resource.RequiresReplace" has an unsupported argument [1], only strings, numbers and booleans are supported`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := injectedField{}
+			require.NoError(t, decodeConfig([]byte(tt.yaml), &f))
+			require.Equal(t, tt.errs, f.validate())
+		})
+	}
+}
+
+func TestGoCallCode(t *testing.T) {
+	c := goCall{Func: "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator.LengthBetween", Args: []interface{}{1, 10}}
+	require.NoError(t, c.validate())
+	require.Equal(t, "stringvalidator.LengthBetween(1, 10)", c.code().GoString())
+}
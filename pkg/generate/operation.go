@@ -0,0 +1,163 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	j "github.com/dave/jennifer/jen"
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Index records every message in files so long-running operation types can be resolved,
+// which of them are returned by long-running operations so their schemas get a timeouts attribute,
+// and which get a nested attributes helper.
+func (c *Config) Index(files []*protogen.File) {
+	c.messages = map[protoreflect.FullName]*protogen.Message{}
+	var add func(messages []*protogen.Message)
+	add = func(messages []*protogen.Message) {
+		for _, m := range messages {
+			c.messages[m.Desc.FullName()] = m
+			add(m.Messages)
+		}
+	}
+	for _, file := range files {
+		add(file.Messages)
+	}
+
+	c.operationResponses = map[protoreflect.FullName]bool{}
+	for _, file := range files {
+		for _, s := range file.Services {
+			for _, method := range s.Methods {
+				if info := operationInfo(method); info != nil {
+					if m, err := c.operationType(method, info.ResponseType); err == nil {
+						c.operationResponses[m.Desc.FullName()] = true
+					}
+				}
+			}
+		}
+	}
+	c.indexHelpers(files)
+}
+
+// operationInfo returns the google.longrunning.operation_info option of the method, or nil if it isn't set.
+func operationInfo(method *protogen.Method) *longrunning.OperationInfo {
+	opts, ok := method.Desc.Options().(*descriptorpb.MethodOptions)
+	if !ok || opts == nil {
+		return nil
+	}
+	info, _ := proto.GetExtension(opts, longrunning.E_OperationInfo).(*longrunning.OperationInfo)
+	if info == nil || (info.ResponseType == "" && info.MetadataType == "") {
+		return nil
+	}
+	return info
+}
+
+// operationType resolves an operation_info type name, which is relative to the method's package unless it is fully qualified.
+func (c *Config) operationType(method *protogen.Method, name string) (*protogen.Message, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%s: google.longrunning.operation_info response_type is required", location(method.Desc))
+	}
+	pkg := method.Desc.ParentFile().Package()
+	for _, candidate := range []protoreflect.FullName{pkg.Append(protoreflect.Name(name)), protoreflect.FullName(name)} {
+		if m, ok := c.messages[candidate]; ok && candidate.IsValid() {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("%s: unable to resolve google.longrunning.operation_info response_type %q", location(method.Desc), name)
+}
+
+// operationResponse reports whether the message is returned by a long-running operation.
+func (c *Config) operationResponse(m *protogen.Message) bool {
+	return c.operationResponses[m.Desc.FullName()]
+}
+
+// operationTimeout returns the timeouts attribute that bounds waiting on the method's operation: the one configured
+// with operationTimeouts, or the one matching the start of its name.
+func (c *Config) operationTimeout(method *protogen.Method) (string, error) {
+	if timeout, ok := c.OperationTimeouts[string(method.Desc.FullName())]; ok {
+		return timeout, nil
+	}
+	for _, op := range timeoutOperations {
+		if strings.HasPrefix(method.GoName, op.prefix) {
+			return op.timeout, nil
+		}
+	}
+	return "", fmt.Errorf("%s: unable to choose a timeout for long-running RPC %s as its name doesn't start with Create, Get, Update or Delete, set one with operationTimeouts", location(method.Desc), method.Desc.FullName())
+}
+
+// Operations generates a Wait<Method> function for every RPC in the file annotated with google.longrunning.operation_info.
+// They poll the returned operation until it is done, bounded by the RPC's timeouts attribute, and unpack its response.
+// Resources call them from their own CRUD methods, they aren't wired in by the generator.
+func Operations(f *j.File, file *protogen.File, cfg *Config) error {
+	errs := schemaErrors{}
+	for _, s := range file.Services {
+		for _, method := range s.Methods {
+			info := operationInfo(method)
+			if info == nil {
+				continue
+			}
+			l := log.With().Str("generator", "Operations").Str("proto", method.GoName).Logger()
+			l.Debug().Msg("Generating operation wait")
+
+			response, err := cfg.operationType(method, info.ResponseType)
+			if err != nil {
+				errs.add(err)
+				continue
+			}
+			timeout, err := cfg.operationTimeout(method)
+			if err != nil {
+				errs.add(err)
+				continue
+			}
+			// Use the response's configured default, Empty and other responses without timeouts use the runtime default.
+			msgCfg := cfg.messageConfig(response)
+
+			id := "Wait" + method.GoName
+			typ := j.Op("*").Qual(string(response.GoIdent.GoImportPath), response.GoIdent.GoName)
+			f.Commentf("// %v waits for a %v operation to complete and returns its %v response.\n"+
+				"// It is bounded by the %v timeout in getter, usually the plan or state, or %v if it isn't set.\n",
+				id, method.GoName, response.GoIdent.GoName, timeout, msgCfg.Timeouts.defaultName(timeout)).
+				Func().Id(id).
+				Params(
+					j.Id("ctx").Qual("context", "Context"),
+					j.Id("client").Qual(Runtime, "OperationGetter"),
+					j.Id("op").Op("*").Qual(LongRunning, "Operation"),
+					j.Id("getter").Qual(Runtime, "AttributeGetter"),
+				).
+				Params(typ, j.Qual(Diag, "Diagnostics")).
+				Block(
+					j.List(j.Id("timeout"), j.Id("diags")).Op(":=").Qual(Runtime, "Timeout").Call(
+						j.Id("ctx"), j.Id("getter"), j.Lit(timeout), msgCfg.Timeouts.defaultCode(timeout),
+					),
+					j.If(j.Id("diags").Dot("HasError").Call()).Block(j.Return(j.Nil(), j.Id("diags"))),
+					j.Id("response").Op(":=").Op("&").Qual(string(response.GoIdent.GoImportPath), response.GoIdent.GoName).Values(),
+					j.Id("diags").Dot("Append").Call(
+						j.Qual(Runtime, "WaitOperation").Call(j.Id("ctx"), j.Id("client"), j.Id("op"), j.Id("timeout"), j.Id("response")).Op("..."),
+					),
+					j.If(j.Id("diags").Dot("HasError").Call()).Block(j.Return(j.Nil(), j.Id("diags"))),
+					j.Return(j.Id("response"), j.Id("diags")),
+				)
+		}
+	}
+	return errs.err()
+}
@@ -0,0 +1,223 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	j "github.com/dave/jennifer/jen"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/rs/zerolog/log"
+)
+
+// resourcePattern is a parsed google.api.resource name pattern, e.g. projects/{project}/widgets/{widget}.
+type resourcePattern struct {
+	pattern   string
+	segments  []string
+	variables []string
+}
+
+// resourceDescriptor returns the google.api.resource option of the message, or nil if it isn't a resource.
+func resourceDescriptor(m *protogen.Message) *annotations.ResourceDescriptor {
+	opts, ok := m.Desc.Options().(*descriptorpb.MessageOptions)
+	if !ok || opts == nil {
+		return nil
+	}
+	r, _ := proto.GetExtension(opts, annotations.E_Resource).(*annotations.ResourceDescriptor)
+	if r == nil || len(r.Pattern) == 0 {
+		return nil
+	}
+	return r
+}
+
+// resourceNamePattern returns the name pattern of a resource message, or nil if it isn't a resource.
+// Only the first pattern of multi-pattern resources is used.
+func resourceNamePattern(m *protogen.Message) (*resourcePattern, error) {
+	r := resourceDescriptor(m)
+	if r == nil {
+		return nil, nil
+	}
+	p, err := parseResourcePattern(r.Pattern[0])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", location(m.Desc), err)
+	}
+	return p, nil
+}
+
+func parseResourcePattern(pattern string) (*resourcePattern, error) {
+	p := &resourcePattern{pattern: pattern, segments: strings.Split(pattern, "/")}
+	for _, segment := range p.segments {
+		if !strings.HasPrefix(segment, "{") {
+			if segment == "" || strings.ContainsAny(segment, "{}=*") {
+				return nil, fmt.Errorf("unsupported google.api.resource pattern %q, only literals and {variable} segments are supported", pattern)
+			}
+			continue
+		}
+		variable := strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "}")
+		if !strings.HasSuffix(segment, "}") || !attributeName.MatchString(variable) {
+			return nil, fmt.Errorf("unsupported google.api.resource pattern %q, only literals and {variable} segments are supported", pattern)
+		}
+		p.variables = append(p.variables, variable)
+	}
+	if len(p.variables) == 0 {
+		return nil, fmt.Errorf("google.api.resource pattern %q has no variables", pattern)
+	}
+	return p, nil
+}
+
+// resourceNameField returns the string field holding a resource message's name, its google.api.resource name_field
+// or name by default, or nil if the message doesn't have one.
+func resourceNameField(m *protogen.Message) *protogen.Field {
+	r := resourceDescriptor(m)
+	if r == nil {
+		return nil
+	}
+	name := r.GetNameField()
+	if name == "" {
+		name = "name"
+	}
+	for _, f := range m.Fields {
+		if string(f.Desc.Name()) == name && f.Desc.Kind() == protoreflect.StringKind && !f.Desc.IsList() {
+			return f
+		}
+	}
+	return nil
+}
+
+// segmentFields returns the singular string fields whose attribute is named like a variable of the pattern, keyed by
+// variable, e.g. the project field of projects/{project}/widgets/{widget}. The field's attribute is used as the
+// variable's segment attribute instead of adding one.
+func segmentFields(msgCfg config, m *protogen.Message, p *resourcePattern) map[string]*protogen.Field {
+	fields := map[string]*protogen.Field{}
+	nameField := resourceNameField(m)
+	for _, f := range m.Fields {
+		if f == nameField || msgCfg.excluded(f) || f.Desc.Kind() != protoreflect.StringKind || f.Desc.IsList() || f.Desc.IsMap() {
+			continue
+		}
+		for _, v := range p.variables {
+			if v == msgCfg.attributeName(f) {
+				fields[v] = f
+			}
+		}
+	}
+	return fields
+}
+
+// resourceAttributes returns the computed id attribute and an attribute per pattern variable, keyed by name.
+func resourceAttributes(p *resourcePattern) map[string]*attribute {
+	attrs := map[string]*attribute{
+		"id": {
+			Description:   fmt.Sprintf("Full resource name, %s.", p.pattern),
+			Computed:      true,
+			Type:          &attributeType{Kind: "string"},
+			planModifiers: []j.Code{j.Qual(Resource, "UseStateForUnknown").Call()},
+		},
+	}
+	for _, v := range p.variables {
+		attrs[v] = &attribute{
+			Description: fmt.Sprintf("The {%s} segment of the resource name.", v),
+			Optional:    true,
+			Computed:    true,
+			Type:        &attributeType{Kind: "string"},
+			planModifiers: []j.Code{
+				j.Qual(Resource, "UseStateForUnknown").Call(),
+				j.Qual(Resource, "RequiresReplace").Call(),
+			},
+		}
+	}
+	return attrs
+}
+
+// ResourceName generates a <Message>ResourceName type, with helpers to build and parse it, for messages annotated
+// with google.api.resource. Invalid patterns are reported by Scheme.
+func ResourceName(f *j.File, m *protogen.Message) {
+	p, err := resourceNamePattern(m)
+	if p == nil || err != nil {
+		return
+	}
+	l := log.With().Str("generator", "ResourceName").Str("proto", m.GoIdent.GoName).Logger()
+	l.Debug().Msg("Generating resource name")
+
+	typ := resourceNameType(m)
+	fields := []j.Code{}
+	values := j.Dict{}
+	for _, v := range p.variables {
+		fields = append(fields, j.Id(goName(v)).String())
+		values[j.Id(goName(v))] = j.Id("values").Index(j.Lit(v))
+	}
+
+	// Concatenate the literals and fields in pattern order to build the name.
+	parts := []j.Code{}
+	literal := ""
+	for i, segment := range p.segments {
+		if i > 0 {
+			literal += "/"
+		}
+		if !strings.HasPrefix(segment, "{") {
+			literal += segment
+			continue
+		}
+		if literal != "" {
+			parts = append(parts, j.Lit(literal))
+			literal = ""
+		}
+		parts = append(parts, j.Id("n").Dot(goName(strings.Trim(segment, "{}"))))
+	}
+	if literal != "" {
+		parts = append(parts, j.Lit(literal))
+	}
+	build := parts[0].(*j.Statement)
+	for _, part := range parts[1:] {
+		build = build.Op("+").Add(part)
+	}
+
+	f.Commentf("// %v is a parsed %v resource name, %v\n", typ, m.GoIdent.GoName, p.pattern).
+		Type().Id(typ).Struct(fields...)
+
+	f.Commentf("// Parse%v parses a %v resource name\n", typ, m.GoIdent.GoName).
+		Func().Id("Parse"+typ).
+		Params(j.Id("name").String()).
+		Params(j.Id(typ), j.Error()).
+		Block(
+			j.List(j.Id("values"), j.Err()).Op(":=").Qual(Runtime, "ParseResourceName").Call(j.Lit(p.pattern), j.Id("name")),
+			j.If(j.Err().Op("!=").Nil()).Block(j.Return(j.Id(typ).Values(), j.Err())),
+			j.Return(j.Id(typ).Values(values), j.Nil()),
+		)
+
+	f.Comment("// String returns the full resource name\n").
+		Func().Params(j.Id("n").Id(typ)).Id("String").Params().String().
+		Block(j.Return(build))
+}
+
+func resourceNameType(m *protogen.Message) string {
+	return m.GoIdent.GoName + "ResourceName"
+}
+
+// goName converts a snake_case name to an exported Go name.
+func goName(s string) string {
+	parts := strings.Split(s, "_")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
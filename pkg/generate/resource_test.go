@@ -0,0 +1,37 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResourcePattern(t *testing.T) {
+	p, err := parseResourcePattern("projects/{project}/locations/{location}/widgets/{widget_id}")
+	require.NoError(t, err)
+	require.Equal(t, []string{"project", "location", "widget_id"}, p.variables)
+
+	for _, pattern := range []string{"projects/{project=**}", "projects//{project}", "projects/{Project}", "projects"} {
+		_, err := parseResourcePattern(pattern)
+		require.Error(t, err, pattern)
+	}
+}
+
+func TestGoName(t *testing.T) {
+	require.Equal(t, "WidgetId", goName("widget_id"))
+	require.Equal(t, "Project", goName("project"))
+}
@@ -15,6 +15,7 @@
 package generate
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -29,126 +30,197 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-func Scheme(f *j.File, m *protogen.Message) {
+func Scheme(f *j.File, m *protogen.Message, cfg *Config) error {
 	id := "GenSchema" + m.GoIdent.GoName
 	l := log.With().Str("generator", "Schema").Str("proto", m.GoIdent.GoName).Logger()
 	l.Debug().Msg("Generating schema")
+	attrs, err := schemaAttributes(l, cfg, m)
+	if err != nil {
+		return err
+	}
+	cfg.useHelpers(attrs)
+	schema := j.Dict{
+		j.Id("Attributes"): attributesCode(attrs),
+	}
+	if msgCfg := cfg.messageConfig(m); msgCfg.Version > 0 {
+		schema[j.Id("Version")] = j.Lit(int(msgCfg.Version))
+	}
 	f.Commentf("// %v returns tfsdk.Schema definition for %v\n", id, m.GoIdent.GoName).
 		Func().
 		Id(id).
 		Params(j.Id("ctx").Qual("context", "Context")).
 		Params(j.Qual(SDK, "Schema"), j.Qual(Diag, "Diagnostics")).
 		Block(j.Return(
-			j.Qual(SDK, "Schema").Values(j.Dict{
-				j.Id("Attributes"): j.Map(j.String()).Qual(SDK, "Attribute").Values(
-					fieldsDictSchema(l, m),
-				),
-			}),
+			j.Qual(SDK, "Schema").Values(schema),
 			j.Nil(),
 		))
+	return nil
 }
 
-func fieldsDictSchema(l zerolog.Logger, m *protogen.Message) j.Dict {
-	cfg := loadConfig(m)
-	d := j.Dict{}
+// schemaAttributes returns the top level attributes of the message's schema.
+func schemaAttributes(l zerolog.Logger, cfg *Config, m *protogen.Message) (map[string]*attribute, error) {
+	return fieldsAttributes(l, cfg, m, true)
+}
+
+// fieldsAttributes returns the attributes for every field of the message, plus any injected via config.
+// root should be true for the top level of a schema, where Terraform meta-argument names are reserved.
+func fieldsAttributes(l zerolog.Logger, cfg *Config, m *protogen.Message, root bool) (map[string]*attribute, error) {
+	attrs := map[string]*attribute{}
+	names := newAttributeSet(string(m.Desc.FullName()))
+	errs := schemaErrors{}
+	msgCfg := cfg.messageConfig(m)
 	for _, f := range m.Fields {
-		// This is a horrible hack to avoid struct infinite recursion
-		if f.Parent.Desc.FullName() == "google.protobuf.Struct" {
-			d[j.Lit(snakeCase(f.GoName))] = j.Values(j.Dict{
-				j.Id("Description"): j.Lit(trimComments(f.Comments.Leading)),
-				j.Id("Type"): j.Qual(Types, "MapType").Values(j.Dict{
-					j.Id("ElemType"): j.Qual(Types, "ObjectType").Values(),
-				}),
-			})
+		if msgCfg.excluded(f) {
+			l.Debug().Msgf("excluding field: %v", f.GoName)
 			continue
 		}
+		name := msgCfg.attributeName(f)
+		names.add(name, location(f.Desc))
+
+		attr, err := field(l, cfg, f)
+		if err == nil {
+			err = setCustomType(msgCfg, f, attr)
+		}
+		errs.add(err)
+		attrs[name] = attr
+	}
 
-		d[j.Lit(snakeCase(f.GoName))] = field(l, f)
+	// Resources get an id attribute and an attribute per segment of their name, but only at the top level.
+	if root {
+		pattern, err := resourceNamePattern(m)
+		errs.add(err)
+		if pattern != nil {
+			source := fmt.Sprintf("%s: google.api.resource pattern %q", location(m.Desc), pattern.pattern)
+			resourceAttrs := resourceAttributes(pattern)
+			fields := segmentFields(msgCfg, m, pattern)
+			for _, name := range append([]string{"id"}, pattern.variables...) {
+				// A field named like the segment is its segment attribute, changing it still replaces the resource.
+				if fields[name] != nil {
+					if attr := attrs[name]; attr != nil {
+						attr.planModifiers = append(attr.planModifiers, j.Qual(Resource, "RequiresReplace").Call())
+					}
+					continue
+				}
+				names.add(name, source)
+				attrs[name] = resourceAttrs[name]
+			}
+			// The name field is built from the segments, so it is computed unless it is set.
+			if f := resourceNameField(m); f != nil && !msgCfg.excluded(f) {
+				if attr := attrs[msgCfg.attributeName(f)]; attr != nil && !attr.Required {
+					attr.Computed = true
+					attr.planModifiers = append(attr.planModifiers, j.Qual(Resource, "UseStateForUnknown").Call())
+				}
+			}
+		}
+		if hasTimeouts(cfg, msgCfg, m) {
+			names.add("timeouts", fmt.Sprintf("%s: timeouts", location(m.Desc)))
+			attrs["timeouts"] = timeoutsAttribute()
+		}
 	}
 
-	for key, value := range cfg.InjectedFields {
-		d[j.Lit(snakeCase(key))] = generateInjectedField(l, value)
+	for _, key := range sortedKeys(msgCfg.InjectedFields) {
+		name := snakeCase(key)
+		names.add(name, msgCfg.InjectedFields[key].source)
+		attr, err := injectedAttribute(l, msgCfg.InjectedFields[key])
+		errs.add(err)
+		attrs[name] = attr
 	}
 
-	return d
+	errs.add(names.validate(root))
+	return attrs, errs.err()
 }
 
-func field(l zerolog.Logger, f *protogen.Field) j.Code {
+func field(l zerolog.Logger, cfg *Config, f *protogen.Field) (*attribute, error) {
 	l.Debug().Msgf("handling field: %v", f.GoName)
 
-	d := j.Dict{
-		j.Id("Description"): j.Lit(trimComments(f.Comments.Leading)),
-		j.Id("Type"):        schemaType(l, f.Desc), // nils are automatically omitted
-		j.Id("Attributes"):  attributes(l, f),
+	attr := &attribute{
+		Description: trimComments(f.Comments.Leading),
+		Type:        schemaType(l, f.Desc),
+		Field:       string(f.Desc.Name()),
+		enum:        f.Desc.Enum(),
+	}
+	if err := attributes(l, cfg, f, attr); err != nil {
+		return nil, err
 	}
 
 	// Handle field behavior annotations
-	opts := f.Desc.Options().(*descriptorpb.FieldOptions)
 	optional := true
-	for _, b := range proto.GetExtension(opts, annotations.E_FieldBehavior).([]annotations.FieldBehavior) {
+	for _, b := range fieldBehaviors(f) {
 		switch b {
 		case annotations.FieldBehavior_REQUIRED:
-			d[j.Id("Required")] = j.Lit(true)
+			attr.Required = true
+			optional = false
+		case annotations.FieldBehavior_OUTPUT_ONLY:
+			attr.Computed = true
 			optional = false
 		}
 	}
 	// If required or computed is not set, default to optional
 	if optional {
-		d[j.Id("Optional")] = j.Lit(true)
+		attr.Optional = true
 	}
 
-	return j.Values(d)
+	return attr, nil
 }
 
-var primitiveTypeMap = map[protoreflect.Kind]*j.Statement{
-	protoreflect.StringKind: j.Qual(Types, "StringType"),
-	protoreflect.BytesKind:  j.Qual(Types, "StringType"),
-	protoreflect.Int32Kind:  j.Qual(Types, "Int64Type"),
-	protoreflect.Int64Kind:  j.Qual(Types, "Int64Type"),
-	protoreflect.EnumKind:   j.Qual(Types, "Int64Type"),
-	protoreflect.FloatKind:  j.Qual(Types, "Float64Type"),
-	protoreflect.DoubleKind: j.Qual(Types, "Float64Type"),
-	protoreflect.BoolKind:   j.Qual(Types, "BoolType"),
+func fieldBehaviors(f *protogen.Field) []annotations.FieldBehavior {
+	opts := f.Desc.Options().(*descriptorpb.FieldOptions)
+	return proto.GetExtension(opts, annotations.E_FieldBehavior).([]annotations.FieldBehavior)
 }
 
-func generateInjectedField(l zerolog.Logger, f injectedField) j.Code {
-	d := j.Dict{
-		j.Id("Type"):     j.Id(f.Type),
-		j.Id("Required"): j.Lit(f.Required),
-		j.Id("Computed"): j.Lit(f.Computed),
-		j.Id("Optional"): j.Lit(f.Optional),
+// outputOnly returns whether the field is annotated OUTPUT_ONLY, so its attribute is computed and can't be set.
+func outputOnly(f *protogen.Field) bool {
+	for _, b := range fieldBehaviors(f) {
+		if b == annotations.FieldBehavior_OUTPUT_ONLY {
+			return true
+		}
 	}
+	return false
+}
 
-	return j.Values(d)
+var primitiveTypeMap = map[protoreflect.Kind]string{
+	protoreflect.StringKind: "string",
+	protoreflect.BytesKind:  "string",
+	protoreflect.Int32Kind:  "int64",
+	protoreflect.Int64Kind:  "int64",
+	protoreflect.EnumKind:   "int64",
+	protoreflect.FloatKind:  "float64",
+	protoreflect.DoubleKind: "float64",
+	protoreflect.BoolKind:   "bool",
 }
 
-func schemaType(l zerolog.Logger, d protoreflect.FieldDescriptor) *j.Statement {
+func schemaType(l zerolog.Logger, d protoreflect.FieldDescriptor) *attributeType {
 	if d.IsList() {
 		// If the type isnt a primitive then type is nil, we use attributes instead.
 		if _, ok := primitiveTypeMap[d.Kind()]; !ok {
 			return nil
 		}
-		return j.Qual(Types, "ListType").Values(j.Dict{
-			j.Id("ElemType"): primitiveTypeMap[d.Kind()],
-		})
+		return &attributeType{Kind: "list", ElemType: &attributeType{Kind: primitiveTypeMap[d.Kind()]}}
 	}
 	if d.IsMap() {
 		// If the type isnt a primitive then type is nil, we use attributes instead.
 		if _, ok := primitiveTypeMap[d.MapValue().Kind()]; !ok {
 			return nil
 		}
-		return j.Qual(Types, "MapType").Values(j.Dict{
-			j.Id("ElemType"): primitiveTypeMap[d.MapValue().Kind()],
-		})
+		return &attributeType{Kind: "map", ElemType: &attributeType{Kind: primitiveTypeMap[d.MapValue().Kind()]}}
+	}
+	if kind, ok := primitiveTypeMap[d.Kind()]; ok {
+		return &attributeType{Kind: kind}
 	}
-	return primitiveTypeMap[d.Kind()]
+	return nil
 }
 
-func attributes(l zerolog.Logger, f *protogen.Field) *j.Statement {
+// attributes sets the nested attributes of message fields.
+func attributes(l zerolog.Logger, cfg *Config, f *protogen.Field, attr *attribute) error {
 	// If message is not nil it can't be a primitive type (string, bool, etc.).
+	// Messages with a type mapping are a typed attribute rather than nested attributes.
 	if f.Message != nil {
 		if f.Desc.IsList() {
-			return xNestAttributes(l, "List", f.Message)
+			if t := cfg.mappedType(f.Message); t != nil {
+				attr.Type = &attributeType{Kind: "list", ElemType: t}
+				return nil
+			}
+			return xNestAttributes(l, cfg, "list", f.Message, attr)
 		}
 		if f.Desc.IsMap() {
 			// If the map has a primitive value we use type, not attributes.
@@ -156,18 +228,36 @@ func attributes(l zerolog.Logger, f *protogen.Field) *j.Statement {
 				return nil
 			}
 			// Not sure how safe the assumption that fields[1] is always value and not key ¯\_(ツ)_/¯.
-			return xNestAttributes(l, "Map", f.Message.Fields[1].Message)
+			if t := cfg.mappedType(f.Message.Fields[1].Message); t != nil {
+				attr.Type = &attributeType{Kind: "map", ElemType: t}
+				return nil
+			}
+			return xNestAttributes(l, cfg, "map", f.Message.Fields[1].Message, attr)
 		}
 		// If we've got this far is must be single nested
-		return xNestAttributes(l, "Single", f.Message)
+		if t := cfg.mappedType(f.Message); t != nil {
+			attr.Type = t
+			if f.Message.Desc.FullName() == "google.protobuf.ListValue" {
+				attr.example = "jsonencode([])"
+			} else if cfg.jsonMessage(f.Message) {
+				attr.example = "jsonencode({})"
+			}
+			return nil
+		}
+		return xNestAttributes(l, cfg, "single", f.Message, attr)
 
 	}
 	return nil
 }
-func xNestAttributes(l zerolog.Logger, typ string, m *protogen.Message) *j.Statement {
-	return j.Qual(SDK, typ+"NestedAttributes").Params(
-		j.Map(j.String()).Qual(SDK, "Attribute").Values(fieldsDictSchema(l, m)),
-	)
+func xNestAttributes(l zerolog.Logger, cfg *Config, nesting string, m *protogen.Message, attr *attribute) error {
+	attrs, err := fieldsAttributes(l, cfg, m, false)
+	if err != nil {
+		return err
+	}
+	attr.Nesting = nesting
+	attr.Attributes = attrs
+	attr.message = m
+	return nil
 }
 
 func trimComments(c protogen.Comments) string {
@@ -188,5 +278,3 @@ func snakeCase(s string) string {
 	snake = matchAllCap.ReplaceAllString(snake, "${1}_${2}")
 	return strings.ToLower(snake)
 }
-
-// func handleStructValue()
@@ -0,0 +1,141 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/rs/zerolog/log"
+)
+
+// providerSchemasJSON is the output of terraform providers schema -json.
+type providerSchemasJSON struct {
+	FormatVersion   string                         `json:"format_version"`
+	ProviderSchemas map[string]*providerSchemaJSON `json:"provider_schemas"`
+}
+
+type providerSchemaJSON struct {
+	ResourceSchemas map[string]*schemaJSON `json:"resource_schemas"`
+}
+
+type schemaJSON struct {
+	Version int64      `json:"version"`
+	Block   *blockJSON `json:"block"`
+}
+
+type blockJSON struct {
+	Attributes      map[string]*attributeJSON `json:"attributes,omitempty"`
+	DescriptionKind string                    `json:"description_kind"`
+}
+
+type attributeJSON struct {
+	Type            json.RawMessage `json:"type,omitempty"`
+	NestedType      *nestedTypeJSON `json:"nested_type,omitempty"`
+	Description     string          `json:"description,omitempty"`
+	DescriptionKind string          `json:"description_kind"`
+	Required        bool            `json:"required,omitempty"`
+	Optional        bool            `json:"optional,omitempty"`
+	Computed        bool            `json:"computed,omitempty"`
+	Sensitive       bool            `json:"sensitive,omitempty"`
+}
+
+type nestedTypeJSON struct {
+	Attributes  map[string]*attributeJSON `json:"attributes"`
+	NestingMode string                    `json:"nesting_mode"`
+}
+
+// SchemaJSON returns the schema of every message in the file, as resources of provider, in the format
+// terraform providers schema -json produces. provider is the provider's source address, e.g.
+// registry.terraform.io/mycorp/widgets, and resources are named <provider type>_<snake cased message name>.
+func SchemaJSON(file *protogen.File, cfg *Config, provider string) ([]byte, error) {
+	resources := map[string]*schemaJSON{}
+	errs := schemaErrors{}
+	for _, m := range cfg.GeneratedMessages(file) {
+		l := log.With().Str("generator", "SchemaJSON").Str("proto", m.GoIdent.GoName).Logger()
+		l.Debug().Msg("Generating schema JSON")
+		msgCfg := cfg.messageConfig(m)
+		attrs, err := schemaAttributes(l, cfg, m)
+		if err != nil {
+			errs.add(err)
+			continue
+		}
+		resources[resourceTypeName(provider, m)] = &schemaJSON{
+			Version: msgCfg.Version,
+			Block:   &blockJSON{Attributes: attributesJSON(attrs), DescriptionKind: "plain"},
+		}
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	contents, err := json.MarshalIndent(providerSchemasJSON{
+		FormatVersion:   "1.0",
+		ProviderSchemas: map[string]*providerSchemaJSON{provider: {ResourceSchemas: resources}},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(contents, '\n'), nil
+}
+
+// resourceTypeName returns the Terraform resource type of the message, e.g. widgets_widget for test.Widget
+// in registry.terraform.io/mycorp/widgets. Nested messages are named after their parents, e.g. widgets_outer_inner.
+func resourceTypeName(provider string, m *protogen.Message) string {
+	return fmt.Sprintf("%s_%s", path.Base(provider), strings.ReplaceAll(snakeCase(m.GoIdent.GoName), "__", "_"))
+}
+
+func attributesJSON(attrs map[string]*attribute) map[string]*attributeJSON {
+	out := make(map[string]*attributeJSON, len(attrs))
+	for name, attr := range attrs {
+		a := &attributeJSON{
+			Description:     attr.Description,
+			DescriptionKind: "plain",
+			Required:        attr.Required,
+			Optional:        attr.Optional,
+			Computed:        attr.Computed,
+			Sensitive:       attr.Sensitive,
+		}
+		if attr.Attributes != nil {
+			a.NestedType = &nestedTypeJSON{Attributes: attributesJSON(attr.Attributes), NestingMode: attr.Nesting}
+		} else if attr.Type != nil {
+			a.Type, _ = json.Marshal(attr.Type.ctyJSON())
+		}
+		out[name] = a
+	}
+	return out
+}
+
+// ctyJSON returns the type in Terraform's JSON type syntax, e.g. "string" or ["list","string"].
+func (t *attributeType) ctyJSON() interface{} {
+	switch t.Kind {
+	case "list", "map", "set":
+		return []interface{}{t.Kind, t.ElemType.ctyJSON()}
+	case "object":
+		attrTypes := map[string]interface{}{}
+		for name, typ := range t.AttrTypes {
+			attrTypes[name] = typ.ctyJSON()
+		}
+		return []interface{}{"object", attrTypes}
+	}
+	if numberKinds[t.Kind] {
+		return "number"
+	}
+	return t.Kind
+}
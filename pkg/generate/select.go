@@ -0,0 +1,90 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/liamawhite/protoc-gen-terraform/extensions/terraform"
+)
+
+var resourceTag = regexp.MustCompile(`\+terraform-gen:resource\b`)
+
+// Selective limits generation to messages marked as Terraform resources, either with the (terraform.resource) option,
+// the +terraform-gen:resource comment tag or a full name matching one of the include patterns. Messages that aren't
+// selected are still inlined into the schemas that use them.
+func (c *Config) Selective(include []string) error {
+	errs := schemaErrors{}
+	for _, pattern := range include {
+		if _, err := path.Match(pattern, ""); pattern == "" || err != nil {
+			errs = append(errs, fmt.Sprintf("include: %q is not a valid message name or pattern", pattern))
+		}
+	}
+	c.selective = true
+	c.include = include
+	return errs.err()
+}
+
+// GeneratedMessages returns the messages of the file that code is generated for, including messages declared inside
+// other messages, which are named like Outer_Inner. Map entries are left out.
+func (c *Config) GeneratedMessages(file *protogen.File) []*protogen.Message {
+	messages := []*protogen.Message{}
+	var add func([]*protogen.Message)
+	add = func(ms []*protogen.Message) {
+		for _, m := range ms {
+			if m.Desc.IsMapEntry() {
+				continue
+			}
+			if c.selected(m) {
+				messages = append(messages, m)
+			}
+			add(m.Messages)
+		}
+	}
+	add(file.Messages)
+	return messages
+}
+
+func (c *Config) selected(m *protogen.Message) bool {
+	return !c.selective || c.marked(m)
+}
+
+// resource returns whether the message is a Terraform resource, rather than only used as nested attributes. Resources
+// are annotated with google.api.resource or marked like the messages selected by Selective.
+func (c *Config) resource(m *protogen.Message) bool {
+	return resourceDescriptor(m) != nil || c.marked(m)
+}
+
+// marked returns whether the message is marked as a Terraform resource, with the (terraform.resource) option, the
+// +terraform-gen:resource comment tag or a full name matching one of the include patterns.
+func (c *Config) marked(m *protogen.Message) bool {
+	if resource, _ := proto.GetExtension(m.Desc.Options(), terraform.E_Resource).(bool); resource {
+		return true
+	}
+	if resourceTag.MatchString(string(m.Comments.Leading)) {
+		return true
+	}
+	for _, pattern := range c.include {
+		if matched, _ := path.Match(pattern, string(m.Desc.FullName())); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/liamawhite/protoc-gen-terraform/extensions/terraform"
+)
+
+func TestGeneratedMessages(t *testing.T) {
+	option := &descriptorpb.MessageOptions{}
+	proto.SetExtension(option, terraform.E_Resource, true)
+	annotated := &descriptorpb.MessageOptions{}
+	proto.SetExtension(annotated, annotations.E_Resource, &annotations.ResourceDescriptor{Pattern: []string{"annotated/{annotated}"}})
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("select.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/test")},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Plain"), NestedType: []*descriptorpb.DescriptorProto{
+				{Name: proto.String("Inner")},
+				{Name: proto.String("LabelsEntry"), Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)}, Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("key"), Number: proto.Int32(1), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()},
+					{Name: proto.String("value"), Number: proto.Int32(2), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()},
+				}},
+			}, Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("labels"), Number: proto.Int32(1), Label: descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(), Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), TypeName: proto.String(".test.Plain.LabelsEntry")},
+			}},
+			{Name: proto.String("Option"), Options: option},
+			{Name: proto.String("Tagged")},
+			{Name: proto.String("Included")},
+			{Name: proto.String("Annotated"), Options: annotated},
+		},
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{Location: []*descriptorpb.SourceCodeInfo_Location{
+			{Path: []int32{4, 2}, Span: []int32{0, 0, 0}, LeadingComments: proto.String(" Tagged is a resource.\n +terraform-gen:resource\n")},
+		}},
+	}
+	gen, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"select.proto"},
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{file},
+	})
+	require.NoError(t, err)
+
+	names := func(cfg *Config) []string {
+		names := []string{}
+		for _, m := range cfg.GeneratedMessages(gen.Files[0]) {
+			names = append(names, m.GoIdent.GoName)
+		}
+		return names
+	}
+
+	t.Run("Every message by default", func(t *testing.T) {
+		require.Equal(t, []string{"Plain", "Plain_Inner", "Option", "Tagged", "Included", "Annotated"}, names(&Config{}))
+	})
+	t.Run("Only resources when selective", func(t *testing.T) {
+		cfg := &Config{}
+		require.NoError(t, cfg.Selective([]string{"test.Incl*", "test.Plain.Inner"}))
+		require.Equal(t, []string{"Plain_Inner", "Option", "Tagged", "Included"}, names(cfg))
+	})
+	t.Run("Resources", func(t *testing.T) {
+		resources := func(cfg *Config) []string {
+			names := []string{}
+			for _, m := range cfg.GeneratedMessages(gen.Files[0]) {
+				if cfg.resource(m) {
+					names = append(names, m.GoIdent.GoName)
+				}
+			}
+			return names
+		}
+		require.Equal(t, []string{"Option", "Tagged", "Annotated"}, resources(&Config{}))
+
+		cfg := &Config{}
+		require.NoError(t, cfg.Selective([]string{"test.Incl*"}))
+		require.Equal(t, []string{"Option", "Tagged", "Included"}, resources(cfg))
+	})
+	t.Run("Invalid include pattern", func(t *testing.T) {
+		require.EqualError(t, (&Config{}).Selective([]string{"test.[", ""}),
+			`include: "test.[" is not a valid message name or pattern`+"\n"+`include: "" is not a valid message name or pattern`)
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/rs/zerolog/log"
+)
+
+// snapshot records the schema of every generated message at every version it has been generated at,
+// so state can be upgraded from prior versions.
+type snapshot struct {
+	// Messages maps full message names to their schemas by version.
+	Messages map[string]map[int64]*snapshotSchema `json:"messages"`
+}
+
+type snapshotSchema struct {
+	Attributes map[string]*attribute `json:"attributes"`
+}
+
+// LoadSnapshot loads the schemas generated by previous runs, relative to the directory protoc is run from.
+// A missing file is treated as an empty snapshot so the first run can create it.
+func (c *Config) LoadSnapshot(filename string) error {
+	s, err := readSnapshot(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		c.snapshot = &snapshot{Messages: map[string]map[int64]*snapshotSchema{}}
+		return nil
+	}
+	c.snapshot = s
+	return err
+}
+
+func readSnapshot(filename string) (*snapshot, error) {
+	contents, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", filename, err)
+	}
+	s := &snapshot{}
+	if err := json.Unmarshal(contents, s); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", filename, err)
+	}
+	if s.Messages == nil {
+		s.Messages = map[string]map[int64]*snapshotSchema{}
+	}
+	return s, nil
+}
+
+// priorSchemas returns the schemas the message was generated with at versions before its current one.
+func (c *Config) priorSchemas(m *protogen.Message, version int64) map[int64]*snapshotSchema {
+	prior := map[int64]*snapshotSchema{}
+	if c.snapshot == nil {
+		return prior
+	}
+	for v, schema := range c.snapshot.Messages[string(m.Desc.FullName())] {
+		if v < version {
+			prior[v] = schema
+		}
+	}
+	return prior
+}
+
+// Snapshot returns the loaded snapshot updated with the current schema of every message in files that are being generated.
+// Schemas at other versions, and of messages that aren't being generated, are kept as they are.
+func (c *Config) Snapshot(files []*protogen.File) ([]byte, error) {
+	s := &snapshot{Messages: map[string]map[int64]*snapshotSchema{}}
+	if c.snapshot != nil {
+		for name, versions := range c.snapshot.Messages {
+			s.Messages[name] = map[int64]*snapshotSchema{}
+			for v, schema := range versions {
+				s.Messages[name][v] = schema
+			}
+		}
+	}
+
+	errs := schemaErrors{}
+	for _, file := range files {
+		if !file.Generate {
+			continue
+		}
+		for _, m := range c.GeneratedMessages(file) {
+			l := log.With().Str("generator", "Snapshot").Str("proto", m.GoIdent.GoName).Logger()
+			msgCfg := c.messageConfig(m)
+			attrs, err := schemaAttributes(l, c, m)
+			if err != nil {
+				errs.add(err)
+				continue
+			}
+			name := string(m.Desc.FullName())
+			if s.Messages[name] == nil {
+				s.Messages[name] = map[int64]*snapshotSchema{}
+			}
+			s.Messages[name][msgCfg.Version] = &snapshotSchema{Attributes: attrs}
+		}
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	contents, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(contents, '\n'), nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	j "github.com/dave/jennifer/jen"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Tests generates a Test<Message>Terraform function for a _terraform_test.go file that checks the message's schema
+// attributes, that every field is either copied to an attribute or excluded, and that a populated message round trips
+// through the copy functions unchanged.
+func Tests(f *j.File, m *protogen.Message, cfg *Config) {
+	l := log.With().Str("generator", "Tests").Str("proto", m.GoIdent.GoName).Logger()
+	l.Debug().Msg("Generating schema tests")
+
+	name := m.GoIdent.GoName
+	schema := "GenSchema" + name
+	copies := "copy" + name
+	fail := func(method, format string, args ...j.Code) *j.Statement {
+		return j.Id("t").Dot(method).Call(append([]j.Code{j.Lit(format)}, args...)...)
+	}
+	checkDiags := func(call j.Code, format string) *j.Statement {
+		return j.If(j.Id("diags").Op(":=").Add(call), j.Id("diags").Dot("HasError").Call()).Block(
+			fail("Fatalf", format, j.Id("diags")),
+		)
+	}
+
+	roundTrip := []j.Code{
+		j.Id("want").Op(":=").Op("&").Id(name).Values(),
+		j.Qual(Runtime, "Populate").Call(j.Id("want").Dot("ProtoReflect").Call(), j.Id(copies)),
+	}
+	// Resource names must match their pattern, so use each variable's name as its value, or the value of the field
+	// that is its segment attribute.
+	if p, err := resourceNamePattern(m); p != nil && err == nil {
+		if nameField := resourceNameField(m); nameField != nil {
+			fields := segmentFields(cfg.messageConfig(m), m, p)
+			segments := j.Dict{}
+			for _, v := range p.variables {
+				var value j.Code = j.Lit(v)
+				if f := fields[v]; f != nil {
+					value = j.Id("want").Dot(f.GoName)
+				}
+				segments[j.Id(goName(v))] = value
+			}
+			roundTrip = append(roundTrip, j.Id("want").Dot(nameField.GoName).Op("=").Id(resourceNameType(m)).Values(segments).Dot("String").Call())
+		}
+	}
+	roundTrip = append(roundTrip,
+		j.Id("tf").Op(":=").Qual(TFTypes, "NewValue").Call(
+			j.Id("schema").Dot("Type").Call().Dot("TerraformType").Call(j.Id("ctx")), j.Nil(),
+		),
+		checkDiags(j.Id("Copy"+name+"ToTerraform").Call(j.Id("ctx"), j.Id("want"), j.Op("&").Id("tf")), "Copy"+name+"ToTerraform: %v"),
+		j.Id("got").Op(":=").Op("&").Id(name).Values(),
+		checkDiags(j.Id("Copy"+name+"FromTerraform").Call(j.Id("ctx"), j.Id("tf"), j.Id("got")), "Copy"+name+"FromTerraform: %v"),
+		j.If(j.Op("!").Qual(Proto, "Equal").Call(j.Id("want"), j.Id("got"))).Block(
+			fail("Errorf", "got %v, want %v", j.Id("got"), j.Id("want")),
+		),
+	)
+
+	f.Commentf("// Test%vTerraform checks the %v schema and copy functions\n", name, name).
+		Func().Id("Test"+name+"Terraform").Params(j.Id("t").Op("*").Qual("testing", "T")).Block(
+		j.Id("ctx").Op(":=").Qual("context", "Background").Call(),
+		j.List(j.Id("schema"), j.Id("diags")).Op(":=").Id(schema).Call(j.Id("ctx")),
+		j.If(j.Id("diags").Dot("HasError").Call()).Block(fail("Fatalf", schema+": %v", j.Id("diags"))),
+		j.Line(),
+		j.Id("t").Dot("Run").Call(j.Lit("Schema attributes are valid"), j.Func().Params(j.Id("t").Op("*").Qual("testing", "T")).Block(
+			checkDiags(j.Qual(Runtime, "CheckSchemaAttributes").Call(j.Id("ctx"), j.Id("schema")), "%v"),
+		)),
+		j.Line(),
+		j.Id("t").Dot("Run").Call(j.Lit("Every field is copied or excluded"), j.Func().Params(j.Id("t").Op("*").Qual("testing", "T")).Block(
+			j.Id("excluded").Op(":=").Map(j.String()).Index().String().Values(excludedFields(cfg, m)),
+			j.If(
+				j.Id("fields").Op(":=").Qual(Runtime, "UncoveredFields").Call(
+					j.Parens(j.Op("&").Id(name).Values()).Dot("ProtoReflect").Call().Dot("Descriptor").Call(),
+					j.Id(copies),
+					j.Id("excluded"),
+				),
+				j.Len(j.Id("fields")).Op(">").Lit(0),
+			).Block(fail("Errorf", "fields aren't copied to an attribute or excluded: %v", j.Id("fields"))),
+		)),
+		j.Line(),
+		j.Id("t").Dot("Run").Call(j.Lit("Round trip"), j.Func().Params(j.Id("t").Op("*").Qual("testing", "T")).Block(roundTrip...)),
+	)
+}
+
+// excludedFields returns the excluded field names of the message and every message nested in it, keyed by full name.
+func excludedFields(cfg *Config, m *protogen.Message) j.Dict {
+	excluded := j.Dict{}
+	visited := map[protoreflect.FullName]bool{}
+	var visit func(m *protogen.Message)
+	visit = func(m *protogen.Message) {
+		if visited[m.Desc.FullName()] {
+			return
+		}
+		visited[m.Desc.FullName()] = true
+		msgCfg := cfg.messageConfig(m)
+		names := []j.Code{}
+		for _, f := range m.Fields {
+			if msgCfg.excluded(f) {
+				names = append(names, j.Lit(string(f.Desc.Name())))
+				continue
+			}
+			nested := f.Message
+			if f.Desc.IsMap() {
+				nested = f.Message.Fields[1].Message
+			}
+			if nested != nil && cfg.mappedType(nested) == nil {
+				visit(nested)
+			}
+		}
+		if len(names) > 0 {
+			excluded[j.Lit(string(m.Desc.FullName()))] = j.Values(names...)
+		}
+	}
+	visit(m)
+	return excluded
+}
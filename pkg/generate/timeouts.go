@@ -0,0 +1,171 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"fmt"
+	"time"
+
+	j "github.com/dave/jennifer/jen"
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/rs/zerolog/log"
+)
+
+// timeoutOperations maps RPC name prefixes to the timeouts attribute that bounds them, in schema order.
+// Long-running RPCs that don't match a prefix must have their timeout set with operationTimeouts.
+var timeoutOperations = []struct{ prefix, timeout string }{
+	{"Create", "create"},
+	{"Get", "read"},
+	{"Update", "update"},
+	{"Delete", "delete"},
+}
+
+// timeoutsConfig adds a timeouts attribute to the schema, the durations are the defaults used when it isn't set.
+type timeoutsConfig struct {
+	Create string `yaml:"create,omitempty"`
+	Read   string `yaml:"read,omitempty"`
+	Update string `yaml:"update,omitempty"`
+	Delete string `yaml:"delete,omitempty"`
+}
+
+func (t *timeoutsConfig) validate() error {
+	errs := schemaErrors{}
+	for _, op := range timeoutOperations {
+		if value := t.get(op.timeout); value != "" {
+			if _, err := time.ParseDuration(value); err != nil {
+				errs = append(errs, fmt.Sprintf("timeouts.%s: %q is not a valid duration, e.g. 30s or 2h45m", op.timeout, value))
+			}
+		}
+	}
+	return errs.err()
+}
+
+// get returns the default for the operation (create, read, update or delete), or an empty string if there isn't one.
+func (t *timeoutsConfig) get(op string) string {
+	if t == nil {
+		return ""
+	}
+	switch op {
+	case "create":
+		return t.Create
+	case "read":
+		return t.Read
+	case "update":
+		return t.Update
+	case "delete":
+		return t.Delete
+	}
+	return ""
+}
+
+// merge returns t overridden by the defaults set in o. Timeouts are enabled if either enables them.
+func (t *timeoutsConfig) merge(o *timeoutsConfig) *timeoutsConfig {
+	if t == nil && o == nil {
+		return nil
+	}
+	merged := timeoutsConfig{}
+	if t != nil {
+		merged = *t
+	}
+	if o != nil {
+		for _, value := range []struct{ from, to *string }{
+			{&o.Create, &merged.Create},
+			{&o.Read, &merged.Read},
+			{&o.Update, &merged.Update},
+			{&o.Delete, &merged.Delete},
+		} {
+			if *value.from != "" {
+				*value.to = *value.from
+			}
+		}
+	}
+	return &merged
+}
+
+// defaultName describes the default timeout for the operation in generated comments.
+func (t *timeoutsConfig) defaultName(op string) string {
+	if value := t.get(op); value != "" {
+		d, _ := time.ParseDuration(value)
+		return d.String()
+	}
+	return "runtime.DefaultTimeout"
+}
+
+// defaultCode returns the default timeout for the operation, e.g. 30 * time.Minute.
+func (t *timeoutsConfig) defaultCode(op string) j.Code {
+	value := t.get(op)
+	if value == "" {
+		return j.Qual(Runtime, "DefaultTimeout")
+	}
+	d, _ := time.ParseDuration(value)
+	for _, unit := range []struct {
+		name     string
+		duration time.Duration
+	}{{"Hour", time.Hour}, {"Minute", time.Minute}, {"Second", time.Second}, {"Millisecond", time.Millisecond}} {
+		if d%unit.duration == 0 {
+			return j.Lit(int(d/unit.duration)).Op("*").Qual("time", unit.name)
+		}
+	}
+	return j.Qual("time", "Duration").Call(j.Lit(int(d)))
+}
+
+// hasTimeouts reports whether the message's schema has a timeouts attribute, either because config enables it or
+// because it is returned by a long-running operation.
+func hasTimeouts(cfg *Config, msgCfg config, m *protogen.Message) bool {
+	return msgCfg.Timeouts != nil || cfg.operationResponse(m)
+}
+
+// timeoutsAttribute is the attribute users set to bound how long each operation is waited on.
+func timeoutsAttribute() *attribute {
+	attrs := map[string]*attribute{}
+	for _, op := range timeoutOperations {
+		attrs[op.timeout] = &attribute{
+			Description: fmt.Sprintf("How long to wait for %s operations, e.g. 30s or 2h45m.", op.timeout),
+			Optional:    true,
+			Type:        &attributeType{Kind: "string"},
+			example:     `"30m"`,
+		}
+	}
+	return &attribute{
+		Description: "How long to wait for operations on the resource.",
+		Optional:    true,
+		Nesting:     "single",
+		Attributes:  attrs,
+	}
+}
+
+// Timeouts generates <Operation>Timeout<Message> accessors, e.g. CreateTimeoutWidget, for messages with a timeouts attribute.
+// They return the duration set in the plan or state, or the configured default if it isn't set.
+func Timeouts(f *j.File, m *protogen.Message, cfg *Config) {
+	msgCfg := cfg.messageConfig(m)
+	if !hasTimeouts(cfg, msgCfg, m) {
+		return
+	}
+	l := log.With().Str("generator", "Timeouts").Str("proto", m.GoIdent.GoName).Logger()
+	l.Debug().Msg("Generating timeouts")
+
+	for _, op := range timeoutOperations {
+		id := goName(op.timeout) + "Timeout" + m.GoIdent.GoName
+		f.Commentf("// %v returns the %v timeout set in getter, usually the plan or state, or %v if it isn't set\n",
+			id, op.timeout, msgCfg.Timeouts.defaultName(op.timeout)).
+			Func().Id(id).
+			Params(j.Id("ctx").Qual("context", "Context"), j.Id("getter").Qual(Runtime, "AttributeGetter")).
+			Params(j.Qual("time", "Duration"), j.Qual(Diag, "Diagnostics")).
+			Block(j.Return(j.Qual(Runtime, "Timeout").Call(
+				j.Id("ctx"), j.Id("getter"), j.Lit(op.timeout), msgCfg.Timeouts.defaultCode(op.timeout),
+			)))
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"path"
+
+	j "github.com/dave/jennifer/jen"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// jsonMessages are always rendered as a JSON encoded string, they can hold any JSON value so have no useful schema.
+var jsonMessages = map[protoreflect.FullName]bool{
+	"google.protobuf.Struct":    true,
+	"google.protobuf.Value":     true,
+	"google.protobuf.ListValue": true,
+}
+
+// builtinMappings are well-known messages always rendered with a runtime type mapping, keyed by message name.
+var builtinMappings = map[protoreflect.FullName]string{
+	"google.protobuf.Timestamp": "TimestampMapping",
+}
+
+// typeMapping renders a message as a single attribute of a Terraform type, e.g. google.type.Date as a string,
+// converted by a pair of Go functions with the signatures runtime.MapType expects.
+type typeMapping struct {
+	typeSpec `yaml:",inline"`
+	// ToTerraform and FromTerraform are fully qualified functions, e.g. github.com/mycorp/conv.DateToTerraform.
+	ToTerraform   string `yaml:"toTerraform"`
+	FromTerraform string `yaml:"fromTerraform"`
+	// Sample is an optional fully qualified function setting a sample value for the generated tests.
+	Sample string `yaml:"sample,omitempty"`
+
+	// runtime is the name of a runtime package mapping used instead of the functions, e.g. JSONMapping.
+	runtime string
+}
+
+func (t typeMapping) validate() error {
+	errs := schemaErrors{}
+	errs.add(t.typeSpec.validate())
+	errs.add(prefixErrors("toTerraform", goCall{Func: t.ToTerraform}.validate()))
+	errs.add(prefixErrors("fromTerraform", goCall{Func: t.FromTerraform}.validate()))
+	if t.Sample != "" {
+		errs.add(prefixErrors("sample", goCall{Func: t.Sample}.validate()))
+	}
+	return errs.err()
+}
+
+// code renders the mapping as a *runtime.TypeMapping.
+func (t typeMapping) code() *j.Statement {
+	if t.runtime != "" {
+		return j.Qual(Runtime, t.runtime)
+	}
+	toPkg, to, _ := goCall{Func: t.ToTerraform}.split()
+	fromPkg, from, _ := goCall{Func: t.FromTerraform}.split()
+	args := []j.Code{t.attributeType().code(), j.Qual(toPkg, to), j.Qual(fromPkg, from)}
+	if t.Sample != "" {
+		samplePkg, sample, _ := goCall{Func: t.Sample}.split()
+		args = append(args, j.Qual(samplePkg, sample))
+	}
+	return j.Qual(Runtime, "MapType").Call(args...)
+}
+
+// typeMapping returns the type mapping of the message, if it has one.
+func (c *Config) typeMapping(m *protogen.Message) (typeMapping, bool) {
+	if m == nil {
+		return typeMapping{}, false
+	}
+	if t, ok := c.TypeMappings[string(m.Desc.FullName())]; ok {
+		return t, true
+	}
+	if mapping, ok := builtinMappings[m.Desc.FullName()]; ok {
+		return typeMapping{typeSpec: typeSpec{Type: "string"}, runtime: mapping}, true
+	}
+	if c.jsonMessage(m) {
+		return typeMapping{typeSpec: typeSpec{Type: "string"}, runtime: "JSONMapping"}, true
+	}
+	return typeMapping{}, false
+}
+
+func (c *Config) jsonMessage(m *protogen.Message) bool {
+	if jsonMessages[m.Desc.FullName()] {
+		return true
+	}
+	for _, pattern := range c.JSONMessages {
+		if matched, _ := path.Match(pattern, string(m.Desc.FullName())); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// mappedType returns the attribute type of the message if it has a type mapping, or nil if it doesn't.
+func (c *Config) mappedType(m *protogen.Message) *attributeType {
+	if t, ok := c.typeMapping(m); ok {
+		return t.attributeType()
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	j "github.com/dave/jennifer/jen"
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/rs/zerolog/log"
+)
+
+// UpdateMask generates an UpdateMask<Message> function for resource messages that returns a field mask of the proto
+// fields whose attributes differ between state and plan, for use with AIP Update RPCs. The resource name field and
+// output only fields, whose attributes are computed, are never updated so are left out.
+func UpdateMask(f *j.File, m *protogen.Message, cfg *Config) {
+	if !cfg.resource(m) {
+		return
+	}
+	l := log.With().Str("generator", "UpdateMask").Str("proto", m.GoIdent.GoName).Logger()
+	l.Debug().Msg("Generating update mask")
+
+	id := "UpdateMask" + m.GoIdent.GoName
+	f.Commentf("// %v returns a field mask of the %v fields whose attributes differ between state and plan\n", id, m.GoIdent.GoName).
+		Func().Id(id).
+		Params(
+			j.Id("ctx").Qual("context", "Context"),
+			j.Id("plan").Qual(SDK, "Plan"),
+			j.Id("state").Qual(SDK, "State"),
+		).
+		Params(j.Op("*").Qual(FieldMask, "FieldMask"), j.Qual(Diag, "Diagnostics")).
+		Block(j.Return(j.Qual(Runtime, "UpdateMask").Call(
+			j.Id("ctx"), j.Id("plan"), j.Id("state"),
+			j.Index().Qual(Runtime, "UpdateMaskPath").Values(updateMaskPaths(cfg, m, resourceNameField(m), nil, "")...),
+		)))
+}
+
+// updateMaskPaths maps each field's attribute path to its proto field path, using the same attribute names as the schema.
+// Only singular message fields are descended into, field masks can't address individual list or map entries.
+// nameField, the resource name field of a top level message, is left out.
+func updateMaskPaths(cfg *Config, m *protogen.Message, nameField *protogen.Field, parent *j.Statement, prefix string) []j.Code {
+	msgCfg := cfg.messageConfig(m)
+	paths := []j.Code{}
+	for _, f := range m.Fields {
+		if msgCfg.excluded(f) || f == nameField || outputOnly(f) {
+			continue
+		}
+		attr := j.Qual(Path, "Root").Call(j.Lit(msgCfg.attributeName(f)))
+		if parent != nil {
+			attr = parent.Clone().Dot("AtName").Call(j.Lit(msgCfg.attributeName(f)))
+		}
+		field := prefix + string(f.Desc.Name())
+
+		d := j.Dict{
+			j.Id("Attribute"): attr,
+			j.Id("Field"):     j.Lit(field),
+		}
+		// Messages with a type mapping, including JSON messages, are a single attribute so can't be descended into.
+		if f.Message != nil && !f.Desc.IsList() && !f.Desc.IsMap() && cfg.mappedType(f.Message) == nil {
+			if children := updateMaskPaths(cfg, f.Message, nil, attr, field+"."); len(children) > 0 {
+				d[j.Id("Fields")] = j.Index().Qual(Runtime, "UpdateMaskPath").Values(children...)
+			}
+		}
+		paths = append(paths, j.Values(d))
+	}
+	return paths
+}
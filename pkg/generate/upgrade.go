@@ -0,0 +1,163 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"sort"
+
+	j "github.com/dave/jennifer/jen"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/rs/zerolog/log"
+)
+
+// UpgradeState generates an UpgradeState<Message> function for messages with a schema version, returning a state
+// upgrader from every prior version of the schema in the snapshot. It has the same signature as
+// resource.ResourceWithUpgradeState's UpgradeState so resources can delegate to it.
+func UpgradeState(f *j.File, m *protogen.Message, cfg *Config) {
+	msgCfg := cfg.messageConfig(m)
+	if msgCfg.Version == 0 {
+		return
+	}
+	l := log.With().Str("generator", "UpgradeState").Str("proto", m.GoIdent.GoName).Logger()
+	l.Debug().Msg("Generating state upgraders")
+
+	current, err := schemaAttributes(l, cfg, m)
+	if err != nil {
+		return
+	}
+	prior := cfg.priorSchemas(m, msgCfg.Version)
+	versions := make([]int64, 0, len(prior))
+	for v := range prior {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(a, b int) bool { return versions[a] < versions[b] })
+
+	upgraders := j.Dict{}
+	for _, v := range versions {
+		upgraders[j.Lit(int(v))] = j.Values(j.Dict{
+			j.Id("PriorSchema"): j.Op("&").Qual(SDK, "Schema").Values(j.Dict{
+				j.Id("Version"):    j.Lit(int(v)),
+				j.Id("Attributes"): attributesCode(prior[v].Attributes),
+			}),
+			j.Id("StateUpgrader"): j.Func().
+				Params(
+					j.Id("ctx").Qual("context", "Context"),
+					j.Id("req").Qual(Resource, "UpgradeStateRequest"),
+					j.Id("resp").Op("*").Qual(Resource, "UpgradeStateResponse"),
+				).
+				Block(j.Qual(Runtime, "UpgradeState").Call(
+					j.Id("ctx"), j.Id("req"), j.Id("resp"),
+					j.Index().Qual(Runtime, "StateUpgrade").Values(stateUpgrades(l, current, prior[v].Attributes)...),
+				)),
+		})
+	}
+
+	id := "UpgradeState" + m.GoIdent.GoName
+	f.Commentf("// %v returns state upgraders from every prior version of the %v schema to version %d\n", id, m.GoIdent.GoName, msgCfg.Version).
+		Func().Id(id).
+		Params(j.Id("ctx").Qual("context", "Context")).
+		Map(j.Int64()).Qual(Resource, "StateUpgrader").
+		Block(j.Return(j.Map(j.Int64()).Qual(Resource, "StateUpgrader").Values(upgraders)))
+}
+
+// stateUpgrades maps each current attribute to the prior attribute generated from the same field, or with the same name
+// if it wasn't generated from a field. Attributes that didn't exist, or whose type can't be converted, are left out
+// so they are null after the upgrade.
+func stateUpgrades(l zerolog.Logger, current, prior map[string]*attribute) []j.Code {
+	upgrades := []j.Code{}
+	for _, name := range sortedKeys(current) {
+		attr := current[name]
+		priorName, ok := priorAttribute(name, attr, prior)
+		if !ok {
+			l.Debug().Msgf("attribute %s is new", name)
+			continue
+		}
+		p := prior[priorName]
+		d := j.Dict{
+			j.Id("Attribute"): j.Lit(name),
+			j.Id("Prior"):     j.Lit(priorName),
+		}
+		switch {
+		case attr.Attributes != nil && p.Attributes != nil && attr.Nesting == p.Nesting:
+			if nested := stateUpgrades(l, attr.Attributes, p.Attributes); len(nested) > 0 {
+				d[j.Id("Attributes")] = j.Index().Qual(Runtime, "StateUpgrade").Values(nested...)
+			}
+		case attr.Type != nil && p.Type != nil && convertible(p.Type, attr.Type):
+			if attr.enum != nil && p.Type.Kind == "int64" && attr.Type.Kind == "string" {
+				values := j.Dict{}
+				for i := 0; i < attr.enum.Values().Len(); i++ {
+					value := attr.enum.Values().Get(i)
+					values[j.Lit(int(value.Number()))] = j.Lit(string(value.Name()))
+				}
+				d[j.Id("Enum")] = j.Map(j.Int64()).String().Values(values)
+			}
+		default:
+			l.Warn().Msgf("unable to upgrade attribute %s from %s to %s, it will be null", name, p.Type, attr.Type)
+			continue
+		}
+		upgrades = append(upgrades, j.Values(d))
+	}
+	return upgrades
+}
+
+func priorAttribute(name string, attr *attribute, prior map[string]*attribute) (string, bool) {
+	if attr.Field != "" {
+		for _, priorName := range sortedKeys(prior) {
+			if prior[priorName].Field == attr.Field {
+				return priorName, true
+			}
+		}
+	}
+	if p, ok := prior[name]; ok && p.Field == attr.Field {
+		return name, true
+	}
+	return "", false
+}
+
+// numberKinds are the attribute type kinds that are all numbers in Terraform.
+var numberKinds = map[string]bool{"float64": true, "int64": true, "number": true}
+
+// convertible reports whether runtime.UpgradeState can convert a value from one type to another.
+func convertible(from, to *attributeType) bool {
+	primitive := func(kind string) string {
+		if numberKinds[kind] {
+			return "number"
+		}
+		return kind
+	}
+	switch f, t := primitive(from.Kind), primitive(to.Kind); {
+	case f == t && (f == "list" || f == "map" || f == "set"):
+		return convertible(from.ElemType, to.ElemType)
+	case f == "object" || t == "object":
+		if f != t {
+			return false
+		}
+		for name, typ := range to.AttrTypes {
+			if fromTyp, ok := from.AttrTypes[name]; ok && !convertible(fromTyp, typ) {
+				return false
+			}
+		}
+		return true
+	case f == t:
+		return true
+	case f == "string":
+		return t == "number" || t == "bool"
+	case t == "string":
+		return f == "number" || f == "bool"
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertible(t *testing.T) {
+	str := &attributeType{Kind: "string"}
+	i64 := &attributeType{Kind: "int64"}
+	tests := []struct {
+		name     string
+		from, to *attributeType
+		want     bool
+	}{
+		{"Same", str, str, true},
+		{"Numbers", i64, &attributeType{Kind: "float64"}, true},
+		{"Number to string", i64, str, true},
+		{"String to bool", str, &attributeType{Kind: "bool"}, true},
+		{"Elements", &attributeType{Kind: "list", ElemType: i64}, &attributeType{Kind: "list", ElemType: str}, true},
+		{"Collection kinds", &attributeType{Kind: "list", ElemType: str}, &attributeType{Kind: "set", ElemType: str}, false},
+		{"Primitive to collection", str, &attributeType{Kind: "list", ElemType: str}, false},
+		{"Objects", &attributeType{Kind: "object", AttrTypes: map[string]*attributeType{"a": i64}}, &attributeType{Kind: "object", AttrTypes: map[string]*attributeType{"a": str, "b": str}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, convertible(tt.from, tt.to))
+		})
+	}
+}
+
+func TestPriorAttribute(t *testing.T) {
+	prior := map[string]*attribute{
+		"old_name": {Field: "name"},
+		"id":       {},
+		"color":    {Field: "colour"},
+	}
+	tests := []struct {
+		name     string
+		attrName string
+		attr     *attribute
+		prior    string
+		found    bool
+	}{
+		{"Renamed attribute", "name", &attribute{Field: "name"}, "old_name", true},
+		{"Injected attribute", "id", &attribute{}, "id", true},
+		{"Different field", "color", &attribute{Field: "color"}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prior, found := priorAttribute(tt.attrName, tt.attr, prior)
+			require.Equal(t, tt.found, found)
+			require.Equal(t, tt.prior, prior)
+		})
+	}
+}
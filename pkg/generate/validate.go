@@ -0,0 +1,130 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// reservedNames are the meta-arguments Terraform reserves on every resource block.
+// They can't be used as top level attribute names.
+var reservedNames = map[string]bool{
+	"connection":  true,
+	"count":       true,
+	"depends_on":  true,
+	"for_each":    true,
+	"lifecycle":   true,
+	"provider":    true,
+	"provisioner": true,
+}
+
+// attributeSet tracks the attribute names generated for a single level of a schema,
+// along with where each one came from, so that collisions can be reported.
+type attributeSet struct {
+	owner   string
+	sources map[string][]string
+	order   []string
+}
+
+func newAttributeSet(owner string) *attributeSet {
+	return &attributeSet{owner: owner, sources: map[string][]string{}}
+}
+
+func (s *attributeSet) add(name, source string) {
+	if _, ok := s.sources[name]; !ok {
+		s.order = append(s.order, name)
+	}
+	s.sources[name] = append(s.sources[name], source)
+}
+
+// validate returns an error listing every duplicated attribute and, if this is the
+// top level of the schema, every attribute using a reserved name.
+func (s *attributeSet) validate(root bool) error {
+	errs := schemaErrors{}
+	for _, name := range s.order {
+		sources := s.sources[name]
+		if len(sources) > 1 {
+			errs = append(errs, fmt.Sprintf("%s: attribute %q in %s is defined %d times (%s)",
+				sources[0], name, s.owner, len(sources), strings.Join(sources, ", ")))
+		}
+		if root && reservedNames[name] {
+			errs = append(errs, fmt.Sprintf("%s: attribute %q in %s is a reserved Terraform meta-argument",
+				sources[0], name, s.owner))
+		}
+	}
+	return errs.err()
+}
+
+// schemaErrors collects every problem found while generating a schema so they can be
+// reported together rather than one per run.
+type schemaErrors []string
+
+func (e schemaErrors) Error() string {
+	return strings.Join(e, "\n")
+}
+
+func (e *schemaErrors) add(err error) {
+	if err == nil {
+		return
+	}
+	if errs, ok := err.(schemaErrors); ok {
+		*e = append(*e, errs...)
+		return
+	}
+	*e = append(*e, err.Error())
+}
+
+// err returns nil if there are no errors, deduplicating them otherwise.
+// Nested messages are inlined everywhere they are used so the same problem can be found multiple times.
+func (e schemaErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	seen := map[string]bool{}
+	deduped := schemaErrors{}
+	for _, msg := range e {
+		if !seen[msg] {
+			seen[msg] = true
+			deduped = append(deduped, msg)
+		}
+	}
+	return deduped
+}
+
+// prefixErrors prefixes every error with prefix, keeping each on its own line.
+func prefixErrors(prefix string, err error) error {
+	if err == nil {
+		return nil
+	}
+	errs, ok := err.(schemaErrors)
+	if !ok {
+		return fmt.Errorf("%s: %v", prefix, err)
+	}
+	prefixed := make(schemaErrors, len(errs))
+	for i := range errs {
+		prefixed[i] = fmt.Sprintf("%s: %s", prefix, errs[i])
+	}
+	return prefixed
+}
+
+// location returns the file:line:column a descriptor was declared at.
+func location(d protoreflect.Descriptor) string {
+	file := d.ParentFile()
+	loc := file.SourceLocations().ByDescriptor(d)
+	return fmt.Sprintf("%s:%d:%d", file.Path(), loc.StartLine+1, loc.StartColumn+1)
+}
@@ -0,0 +1,55 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributeSet(t *testing.T) {
+	t.Run("No collisions", func(t *testing.T) {
+		s := newAttributeSet("test.Test")
+		s.add("str", "test.proto:1:1")
+		s.add("count", "test.proto:2:1")
+		require.NoError(t, s.validate(false))
+	})
+
+	t.Run("Duplicate attributes", func(t *testing.T) {
+		s := newAttributeSet("test.Test")
+		s.add("str", "test.proto:1:1")
+		s.add("str", "test.yaml: injectedFields.str")
+		require.EqualError(t, s.validate(false),
+			`test.proto:1:1: attribute "str" in test.Test is defined 2 times (test.proto:1:1, test.yaml: injectedFields.str)`)
+	})
+
+	t.Run("Reserved names", func(t *testing.T) {
+		s := newAttributeSet("test.Test")
+		s.add("count", "test.proto:1:1")
+		s.add("for_each", "test.proto:2:1")
+		require.EqualError(t, s.validate(true),
+			`test.proto:1:1: attribute "count" in test.Test is a reserved Terraform meta-argument`+"\n"+
+				`test.proto:2:1: attribute "for_each" in test.Test is a reserved Terraform meta-argument`)
+	})
+}
+
+func TestSchemaErrorsDeduplicated(t *testing.T) {
+	errs := schemaErrors{}
+	errs.add(schemaErrors{"a", "b"})
+	errs.add(schemaErrors{"a"})
+	require.EqualError(t, errs.err(), "a\nb")
+	require.NoError(t, schemaErrors{}.err())
+}
@@ -0,0 +1,473 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FieldCopy maps an attribute to the proto field it is copied to and from.
+type FieldCopy struct {
+	Attribute string
+	Field     string
+	// Attributes map the fields of a message field, or of the messages in a list or map field.
+	Attributes []FieldCopy
+	// Mapping converts messages with a custom type mapping instead of copying their Attributes. String fields only use
+	// its SemanticallyEqual, e.g. JSONStringMapping.
+	Mapping *TypeMapping
+}
+
+// CopyFromTerraform copies a Terraform object, e.g. plan.Raw, into msg. Only known, non-null attributes are set,
+// fields whose attribute is null or unknown are cleared.
+func CopyFromTerraform(ctx context.Context, tf tftypes.Value, msg protoreflect.Message, copies []FieldCopy) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	copyFromTerraform(ctx, path.Empty(), tf, msg, copies, &diags)
+	return diags
+}
+
+func copyFromTerraform(ctx context.Context, p path.Path, tf tftypes.Value, msg protoreflect.Message, copies []FieldCopy, diags *diag.Diagnostics) {
+	if tf.IsNull() || !tf.IsKnown() {
+		return
+	}
+	attrs := map[string]tftypes.Value{}
+	if err := tf.As(&attrs); err != nil {
+		diags.AddAttributeError(p, "Unable to copy from Terraform", err.Error())
+		return
+	}
+	for _, c := range copies {
+		value, ok := attrs[c.Attribute]
+		if !ok {
+			continue
+		}
+		ap := p.AtName(c.Attribute)
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(c.Field))
+		if fd == nil {
+			diags.AddAttributeError(ap, "Unable to copy from Terraform", fmt.Sprintf("%s has no field %s", msg.Descriptor().FullName(), c.Field))
+			continue
+		}
+		msg.Clear(fd)
+		if value.IsNull() || !value.IsKnown() {
+			continue
+		}
+		if err := fieldFromTerraform(ctx, ap, value, msg, fd, c, diags); err != nil {
+			diags.AddAttributeError(ap, "Unable to copy from Terraform", err.Error())
+		}
+	}
+}
+
+func fieldFromTerraform(ctx context.Context, p path.Path, value tftypes.Value, msg protoreflect.Message, fd protoreflect.FieldDescriptor, c FieldCopy, diags *diag.Diagnostics) error {
+	switch {
+	case fd.IsList():
+		elems := []tftypes.Value{}
+		if err := value.As(&elems); err != nil {
+			return err
+		}
+		list := msg.Mutable(fd).List()
+		for i, elem := range elems {
+			if fd.Message() != nil {
+				v := list.NewElement()
+				messageFromTerraform(ctx, p.AtListIndex(i), elem, v.Message(), c, diags)
+				list.Append(v)
+				continue
+			}
+			v, err := protoValue(fd, elem)
+			if err != nil {
+				diags.AddAttributeError(p.AtListIndex(i), "Unable to copy from Terraform", err.Error())
+				continue
+			}
+			list.Append(v)
+		}
+
+	case fd.IsMap():
+		elems := map[string]tftypes.Value{}
+		if err := value.As(&elems); err != nil {
+			return err
+		}
+		m := msg.Mutable(fd).Map()
+		for key, elem := range elems {
+			k, err := protoValue(fd.MapKey(), tftypes.NewValue(tftypes.String, key))
+			if err != nil {
+				diags.AddAttributeError(p.AtMapKey(key), "Unable to copy from Terraform", err.Error())
+				continue
+			}
+			if fd.MapValue().Message() != nil {
+				v := m.NewValue()
+				messageFromTerraform(ctx, p.AtMapKey(key), elem, v.Message(), c, diags)
+				m.Set(k.MapKey(), v)
+				continue
+			}
+			v, err := protoValue(fd.MapValue(), elem)
+			if err != nil {
+				diags.AddAttributeError(p.AtMapKey(key), "Unable to copy from Terraform", err.Error())
+				continue
+			}
+			m.Set(k.MapKey(), v)
+		}
+
+	case fd.Message() != nil:
+		messageFromTerraform(ctx, p, value, msg.Mutable(fd).Message(), c, diags)
+
+	default:
+		v, err := protoValue(fd, value)
+		if err != nil {
+			return err
+		}
+		msg.Set(fd, v)
+	}
+	return nil
+}
+
+func messageFromTerraform(ctx context.Context, p path.Path, value tftypes.Value, msg protoreflect.Message, c FieldCopy, diags *diag.Diagnostics) {
+	if c.Mapping == nil {
+		copyFromTerraform(ctx, p, value, msg, c.Attributes, diags)
+		return
+	}
+	v, err := c.Mapping.Type.ValueFromTerraform(ctx, value)
+	if err != nil {
+		diags.AddAttributeError(p, "Unable to copy from Terraform", err.Error())
+		return
+	}
+	for _, d := range c.Mapping.FromTerraform(ctx, v, msg) {
+		diags.Append(diag.WithPath(p, d))
+	}
+}
+
+// protoValue converts a primitive Terraform value to the field's kind. Map keys are converted from strings.
+// Numbers that aren't whole or don't fit in an integer field's kind are rejected rather than truncated.
+func protoValue(fd protoreflect.FieldDescriptor, value tftypes.Value) (protoreflect.Value, error) {
+	var s string
+	var b bool
+	n := new(big.Float)
+	switch {
+	case value.Type().Is(tftypes.String):
+		if err := value.As(&s); err != nil {
+			return protoreflect.Value{}, err
+		}
+	case value.Type().Is(tftypes.Bool):
+		if err := value.As(&b); err != nil {
+			return protoreflect.Value{}, err
+		}
+	case value.Type().Is(tftypes.Number):
+		if err := value.As(&n); err != nil {
+			return protoreflect.Value{}, err
+		}
+	}
+	// Enums with an EnumType are copied from their value's name.
+	if value.Type().Is(tftypes.String) && fd.Kind() == protoreflect.EnumKind {
+		ev := fd.Enum().Values().ByName(protoreflect.Name(s))
+		if ev == nil {
+			return protoreflect.Value{}, fmt.Errorf("%q is not a %s value", s, fd.Enum().FullName())
+		}
+		return protoreflect.ValueOfEnum(ev.Number()), nil
+	}
+	// Map keys are the only other strings converted to other kinds.
+	if value.Type().Is(tftypes.String) && fd.Kind() != protoreflect.StringKind && fd.Kind() != protoreflect.BytesKind {
+		switch fd.Kind() {
+		case protoreflect.BoolKind:
+			parsed, err := strconv.ParseBool(s)
+			if err != nil {
+				return protoreflect.Value{}, err
+			}
+			b = parsed
+		default:
+			parsed, _, err := big.ParseFloat(s, 10, 512, big.ToNearestEven)
+			if err != nil {
+				return protoreflect.Value{}, err
+			}
+			n = parsed
+		}
+	}
+
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(s)), nil
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(b), nil
+	case protoreflect.EnumKind:
+		i, err := intValue(fd, n, math.MinInt32, math.MaxInt32)
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(i)), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		i, err := intValue(fd, n, math.MinInt32, math.MaxInt32)
+		return protoreflect.ValueOfInt32(int32(i)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		i, err := intValue(fd, n, math.MinInt64, math.MaxInt64)
+		return protoreflect.ValueOfInt64(i), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		i, err := uintValue(fd, n, math.MaxUint32)
+		return protoreflect.ValueOfUint32(uint32(i)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		i, err := uintValue(fd, n, math.MaxUint64)
+		return protoreflect.ValueOfUint64(i), err
+	case protoreflect.FloatKind:
+		f, _ := n.Float32()
+		return protoreflect.ValueOfFloat32(f), nil
+	case protoreflect.DoubleKind:
+		f, _ := n.Float64()
+		return protoreflect.ValueOfFloat64(f), nil
+	}
+	return protoreflect.Value{}, fmt.Errorf("unable to copy %s to a %s field", value.Type(), fd.Kind())
+}
+
+func intValue(fd protoreflect.FieldDescriptor, n *big.Float, min, max int64) (int64, error) {
+	i, accuracy := n.Int64()
+	if accuracy != big.Exact || i < min || i > max {
+		return 0, fmt.Errorf("%s isn't a valid %s, it must be a whole number from %d to %d", n.Text('f', -1), fd.Kind(), min, max)
+	}
+	return i, nil
+}
+
+func uintValue(fd protoreflect.FieldDescriptor, n *big.Float, max uint64) (uint64, error) {
+	i, accuracy := n.Uint64()
+	if accuracy != big.Exact || i > max {
+		return 0, fmt.Errorf("%s isn't a valid %s, it must be a whole number from 0 to %d", n.Text('f', -1), fd.Kind(), max)
+	}
+	return i, nil
+}
+
+// CopyToTerraform copies msg into a Terraform object, e.g. state.Raw, which must already have the schema's type.
+// Fields with presence that aren't set are null. The zero value of a field without presence, and an empty list or
+// map, keep the attribute's prior null or empty value, so optional attributes left unset or set to [] in config
+// don't change. Otherwise zero values are copied and empty lists and maps are null. Attributes that aren't copied
+// from a field keep their value.
+func CopyToTerraform(ctx context.Context, msg protoreflect.Message, tf *tftypes.Value, copies []FieldCopy) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	if tf.Type() == nil {
+		diags.AddError("Unable to copy to Terraform", "The Terraform value has no type, it should be the raw value of a plan or state.")
+		return diags
+	}
+	*tf = copyToTerraform(ctx, path.Empty(), msg, tf.Type(), *tf, copies, &diags)
+	return diags
+}
+
+func copyToTerraform(ctx context.Context, p path.Path, msg protoreflect.Message, typ tftypes.Type, prior tftypes.Value, copies []FieldCopy, diags *diag.Diagnostics) tftypes.Value {
+	obj, ok := typ.(tftypes.Object)
+	if !ok {
+		diags.AddAttributeError(p, "Unable to copy to Terraform", fmt.Sprintf("%s is not an object", typ))
+		return tftypes.NewValue(typ, nil)
+	}
+	priorAttrs := map[string]tftypes.Value{}
+	if !prior.IsNull() && prior.IsKnown() {
+		if err := prior.As(&priorAttrs); err != nil {
+			diags.AddAttributeError(p, "Unable to copy to Terraform", err.Error())
+		}
+	}
+	attrs := make(map[string]tftypes.Value, len(obj.AttributeTypes))
+	for name, attrType := range obj.AttributeTypes {
+		if value, ok := priorAttrs[name]; ok {
+			attrs[name] = value
+			continue
+		}
+		attrs[name] = tftypes.NewValue(attrType, nil)
+	}
+	for _, c := range copies {
+		attrType, ok := obj.AttributeTypes[c.Attribute]
+		if !ok {
+			continue
+		}
+		ap := p.AtName(c.Attribute)
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(c.Field))
+		if fd == nil {
+			diags.AddAttributeError(ap, "Unable to copy to Terraform", fmt.Sprintf("%s has no field %s", msg.Descriptor().FullName(), c.Field))
+			continue
+		}
+		value, err := fieldToTerraform(ctx, ap, msg, fd, attrType, priorAttrs[c.Attribute], c, diags)
+		if err != nil {
+			diags.AddAttributeError(ap, "Unable to copy to Terraform", err.Error())
+			continue
+		}
+		attrs[c.Attribute] = value
+	}
+	return tftypes.NewValue(obj, attrs)
+}
+
+func fieldToTerraform(ctx context.Context, p path.Path, msg protoreflect.Message, fd protoreflect.FieldDescriptor, typ tftypes.Type, prior tftypes.Value, c FieldCopy, diags *diag.Diagnostics) (tftypes.Value, error) {
+	switch {
+	case fd.IsList():
+		list := msg.Get(fd).List()
+		priorElems := []tftypes.Value{}
+		if !prior.IsNull() && prior.IsKnown() {
+			_ = prior.As(&priorElems)
+		}
+		if list.Len() == 0 {
+			return emptyValue(typ, prior, len(priorElems)), nil
+		}
+		elemType := elementType(typ)
+		elems := make([]tftypes.Value, 0, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			if fd.Message() != nil {
+				var priorElem tftypes.Value
+				if i < len(priorElems) {
+					priorElem = priorElems[i]
+				}
+				elems = append(elems, messageToTerraform(ctx, p.AtListIndex(i), list.Get(i).Message(), elemType, priorElem, c, diags))
+				continue
+			}
+			elem, err := terraformValue(fd, list.Get(i), elemType)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("[%d]: %w", i, err)
+			}
+			elems = append(elems, elem)
+		}
+		return tftypes.NewValue(typ, elems), nil
+
+	case fd.IsMap():
+		m := msg.Get(fd).Map()
+		priorElems := map[string]tftypes.Value{}
+		if !prior.IsNull() && prior.IsKnown() {
+			_ = prior.As(&priorElems)
+		}
+		if m.Len() == 0 {
+			return emptyValue(typ, prior, len(priorElems)), nil
+		}
+		elemType := typ.(tftypes.Map).ElementType
+		elems := make(map[string]tftypes.Value, m.Len())
+		var err error
+		m.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			key := k.String()
+			if fd.MapValue().Message() != nil {
+				elems[key] = messageToTerraform(ctx, p.AtMapKey(key), v.Message(), elemType, priorElems[key], c, diags)
+				return true
+			}
+			elems[key], err = terraformValue(fd.MapValue(), v, elemType)
+			if err != nil {
+				err = fmt.Errorf("[%q]: %w", key, err)
+			}
+			return err == nil
+		})
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+		return tftypes.NewValue(typ, elems), nil
+
+	case !msg.Has(fd) && (fd.Message() != nil || fd.HasPresence()):
+		return tftypes.NewValue(typ, nil), nil
+
+	// The zero value of a field without presence stays null if the attribute was null, e.g. not set in config.
+	case !msg.Has(fd) && prior.IsNull():
+		return tftypes.NewValue(typ, nil), nil
+
+	case fd.Message() != nil:
+		return messageToTerraform(ctx, p, msg.Get(fd).Message(), typ, prior, c, diags), nil
+	}
+	value, err := terraformValue(fd, msg.Get(fd), typ)
+	if err != nil || c.Mapping == nil {
+		return value, err
+	}
+	if v, err := c.Mapping.Type.ValueFromTerraform(ctx, value); err == nil && semanticallyEqual(ctx, c.Mapping, prior, v) {
+		return prior, nil
+	}
+	return value, nil
+}
+
+// emptyValue returns the prior value of an empty list or map field if it was also empty, or null otherwise.
+func emptyValue(typ tftypes.Type, prior tftypes.Value, priorLen int) tftypes.Value {
+	if !prior.IsNull() && prior.IsKnown() && priorLen == 0 {
+		return prior
+	}
+	return tftypes.NewValue(typ, nil)
+}
+
+func messageToTerraform(ctx context.Context, p path.Path, msg protoreflect.Message, typ tftypes.Type, prior tftypes.Value, c FieldCopy, diags *diag.Diagnostics) tftypes.Value {
+	if c.Mapping == nil {
+		return copyToTerraform(ctx, p, msg, typ, prior, c.Attributes, diags)
+	}
+	v, d := c.Mapping.ToTerraform(ctx, msg)
+	for _, d := range d {
+		diags.Append(diag.WithPath(p, d))
+	}
+	if d.HasError() {
+		return tftypes.NewValue(typ, nil)
+	}
+	value, err := v.ToTerraformValue(ctx)
+	if err == nil && !value.Type().Equal(typ) {
+		err = fmt.Errorf("expected a %s, got a %s", typ, value.Type())
+	}
+	if err != nil {
+		diags.AddAttributeError(p, "Unable to copy to Terraform", err.Error())
+		return tftypes.NewValue(typ, nil)
+	}
+	if semanticallyEqual(ctx, c.Mapping, prior, v) {
+		return prior
+	}
+	return value
+}
+
+// semanticallyEqual returns whether the prior value is known, not null and semantically equal to v by the mapping's
+// SemanticallyEqual, if it has one.
+func semanticallyEqual(ctx context.Context, mapping *TypeMapping, prior tftypes.Value, v attr.Value) bool {
+	if mapping.SemanticallyEqual == nil || prior.Type() == nil || prior.IsNull() || !prior.IsKnown() {
+		return false
+	}
+	priorValue, err := mapping.Type.ValueFromTerraform(ctx, prior)
+	return err == nil && mapping.SemanticallyEqual(ctx, priorValue, v)
+}
+
+// terraformValue converts a primitive field value to a Terraform value of typ.
+func terraformValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, typ tftypes.Type) (tftypes.Value, error) {
+	var value interface{}
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		value = v.String()
+	case protoreflect.BytesKind:
+		value = string(v.Bytes())
+	case protoreflect.BoolKind:
+		value = v.Bool()
+	case protoreflect.EnumKind:
+		// Enums with an EnumType are copied as their value's name.
+		if typ.Is(tftypes.String) {
+			ev := fd.Enum().Values().ByNumber(v.Enum())
+			if ev == nil {
+				return tftypes.Value{}, fmt.Errorf("%d is not a %s value", v.Enum(), fd.Enum().FullName())
+			}
+			value = string(ev.Name())
+			break
+		}
+		value = new(big.Float).SetInt64(int64(v.Enum()))
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		value = new(big.Float).SetInt64(v.Int())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		value = new(big.Float).SetUint64(v.Uint())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		if math.IsNaN(v.Float()) || math.IsInf(v.Float(), 0) {
+			return tftypes.Value{}, fmt.Errorf("%v can't be represented in Terraform", v.Float())
+		}
+		if fd.Kind() == protoreflect.DoubleKind {
+			value = big.NewFloat(v.Float())
+			break
+		}
+		// Format with float32 precision so 0.1 isn't copied as 0.10000000149011612.
+		f, _ := strconv.ParseFloat(strconv.FormatFloat(v.Float(), 'g', -1, 32), 64)
+		value = big.NewFloat(f)
+	default:
+		return tftypes.Value{}, fmt.Errorf("unable to copy a %s field", fd.Kind())
+	}
+	if err := tftypes.ValidateValue(typ, value); err != nil {
+		return tftypes.Value{}, err
+	}
+	return tftypes.NewValue(typ, value), nil
+}
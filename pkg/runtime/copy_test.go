@@ -0,0 +1,108 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestProtoValue(t *testing.T) {
+	field := func(m interface{ ProtoReflect() protoreflect.Message }, name string) protoreflect.FieldDescriptor {
+		return m.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(name))
+	}
+	int32Field := field(&wrapperspb.Int32Value{}, "value")
+	int64Field := field(&wrapperspb.Int64Value{}, "value")
+	uint32Field := field(&wrapperspb.UInt32Value{}, "value")
+	uint64Field := field(&wrapperspb.UInt64Value{}, "value")
+	enumField := field(&descriptorpb.FieldDescriptorProto{}, "type")
+	number := func(s string) tftypes.Value {
+		n, _, err := big.ParseFloat(s, 10, 512, big.ToNearestEven)
+		require.NoError(t, err)
+		return tftypes.NewValue(tftypes.Number, n)
+	}
+
+	tests := []struct {
+		name  string
+		field protoreflect.FieldDescriptor
+		value tftypes.Value
+		want  protoreflect.Value
+		err   string
+	}{
+		{"int32", int32Field, number("-2147483648"), protoreflect.ValueOfInt32(-2147483648), ""},
+		{"int32 fraction", int32Field, number("1.5"), protoreflect.Value{}, "1.5 isn't a valid int32, it must be a whole number from -2147483648 to 2147483647"},
+		{"int32 overflow", int32Field, number("3000000000"), protoreflect.Value{}, "3000000000 isn't a valid int32, it must be a whole number from -2147483648 to 2147483647"},
+		{"int64", int64Field, number("9223372036854775807"), protoreflect.ValueOfInt64(9223372036854775807), ""},
+		{"int64 overflow", int64Field, number("9223372036854775808"), protoreflect.Value{}, "9223372036854775808 isn't a valid int64, it must be a whole number from -9223372036854775808 to 9223372036854775807"},
+		{"uint32", uint32Field, number("4294967295"), protoreflect.ValueOfUint32(4294967295), ""},
+		{"uint32 negative", uint32Field, number("-1"), protoreflect.Value{}, "-1 isn't a valid uint32, it must be a whole number from 0 to 4294967295"},
+		{"uint32 overflow", uint32Field, number("4294967296"), protoreflect.Value{}, "4294967296 isn't a valid uint32, it must be a whole number from 0 to 4294967295"},
+		{"uint64", uint64Field, number("18446744073709551615"), protoreflect.ValueOfUint64(18446744073709551615), ""},
+		{"uint64 negative", uint64Field, number("-1"), protoreflect.Value{}, "-1 isn't a valid uint64, it must be a whole number from 0 to 18446744073709551615"},
+		{"uint64 fraction", uint64Field, number("0.5"), protoreflect.Value{}, "0.5 isn't a valid uint64, it must be a whole number from 0 to 18446744073709551615"},
+		{"enum", enumField, number("9"), protoreflect.ValueOfEnum(9), ""},
+		{"enum fraction", enumField, number("1.5"), protoreflect.Value{}, "1.5 isn't a valid enum, it must be a whole number from -2147483648 to 2147483647"},
+		{"enum overflow", enumField, number("3000000000"), protoreflect.Value{}, "3000000000 isn't a valid enum, it must be a whole number from -2147483648 to 2147483647"},
+		{"map key", int64Field, tftypes.NewValue(tftypes.String, "-7"), protoreflect.ValueOfInt64(-7), ""},
+		{"map key fraction", int64Field, tftypes.NewValue(tftypes.String, "7.5"), protoreflect.Value{}, "7.5 isn't a valid int64, it must be a whole number from -9223372036854775808 to 9223372036854775807"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := protoValue(tt.field, tt.value)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want.Interface(), got.Interface())
+		})
+	}
+}
+
+func TestCopyFromTerraformOutOfRange(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Field", func(t *testing.T) {
+		tf := tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"value": tftypes.Number}}, map[string]tftypes.Value{
+			"value": tftypes.NewValue(tftypes.Number, big.NewFloat(1.5)),
+		})
+		diags := CopyFromTerraform(ctx, tf, (&wrapperspb.Int32Value{}).ProtoReflect(), []FieldCopy{{Attribute: "value", Field: "value"}})
+		require.Equal(t, diag.Diagnostics{diag.NewAttributeErrorDiagnostic(path.Root("value"), "Unable to copy from Terraform",
+			"1.5 isn't a valid int32, it must be a whole number from -2147483648 to 2147483647")}, diags)
+	})
+	t.Run("List element", func(t *testing.T) {
+		listType := tftypes.List{ElementType: tftypes.Number}
+		tf := tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"public_dependency": listType}}, map[string]tftypes.Value{
+			"public_dependency": tftypes.NewValue(listType, []tftypes.Value{
+				tftypes.NewValue(tftypes.Number, big.NewFloat(1)),
+				tftypes.NewValue(tftypes.Number, big.NewFloat(3000000000)),
+			}),
+		})
+		msg := &descriptorpb.FileDescriptorProto{}
+		diags := CopyFromTerraform(ctx, tf, msg.ProtoReflect(), []FieldCopy{{Attribute: "public_dependency", Field: "public_dependency"}})
+		require.Equal(t, diag.Diagnostics{diag.NewAttributeErrorDiagnostic(path.Root("public_dependency").AtListIndex(1), "Unable to copy from Terraform",
+			"3000000000 isn't a valid int32, it must be a whole number from -2147483648 to 2147483647")}, diags)
+		require.Equal(t, []int32{1}, msg.PublicDependency)
+	})
+}
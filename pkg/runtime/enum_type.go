@@ -0,0 +1,96 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ xattr.TypeWithValidate = EnumType{}
+
+// EnumType is a string type that only accepts the names of an enum's values, e.g. "OFF". Its values are types.String,
+// the copy functions convert between names and numbers.
+type EnumType struct {
+	// Name is the full name of the enum.
+	Name string
+	// Values are the names of the enum's values, in declaration order.
+	Values []string
+}
+
+func (t EnumType) TerraformType(ctx context.Context) tftypes.Type {
+	return types.StringType.TerraformType(ctx)
+}
+
+func (t EnumType) ValueFromTerraform(ctx context.Context, value tftypes.Value) (attr.Value, error) {
+	return types.StringType.ValueFromTerraform(ctx, value)
+}
+
+func (t EnumType) ValueType(ctx context.Context) attr.Value {
+	return types.StringType.ValueType(ctx)
+}
+
+func (t EnumType) Equal(o attr.Type) bool {
+	other, ok := o.(EnumType)
+	if !ok || other.Name != t.Name || len(other.Values) != len(t.Values) {
+		return false
+	}
+	for i, name := range t.Values {
+		if other.Values[i] != name {
+			return false
+		}
+	}
+	return true
+}
+
+func (t EnumType) String() string {
+	return fmt.Sprintf("EnumType(%s)", t.Name)
+}
+
+func (t EnumType) ApplyTerraform5AttributePathStep(step tftypes.AttributePathStep) (interface{}, error) {
+	return nil, fmt.Errorf("cannot apply AttributePathStep %T to %s", step, t)
+}
+
+// Validate rejects names that aren't one of the enum's values.
+func (t EnumType) Validate(ctx context.Context, value tftypes.Value, p path.Path) diag.Diagnostics {
+	diags := types.StringType.Validate(ctx, value, p)
+	if diags.HasError() || value.IsNull() || !value.IsKnown() {
+		return diags
+	}
+	var s string
+	if err := value.As(&s); err != nil {
+		diags.AddAttributeError(p, "Invalid enum value", err.Error())
+		return diags
+	}
+	for _, name := range t.Values {
+		if name == s {
+			return diags
+		}
+	}
+	valid := make([]string, 0, len(t.Values))
+	for _, name := range t.Values {
+		valid = append(valid, fmt.Sprintf("%q", name))
+	}
+	diags.AddAttributeError(p, "Invalid enum value", fmt.Sprintf("%q is not a %s, must be one of %s", s, t.Name, strings.Join(valid, ", ")))
+	return diags
+}
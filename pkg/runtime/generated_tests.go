@@ -0,0 +1,191 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// The helpers in this file are used by the generated _terraform_test.go files.
+
+var attributeName = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// CheckSchemaAttributes is a partial check of the schema's attributes: attribute names are valid, every attribute has
+// either a type or nested attributes, and is required, or optional and/or computed. It stands in for the framework's
+// ValidateImplementation, which v0.14 doesn't have, but checks nothing else so the framework can still reject a schema
+// that passes it.
+func CheckSchemaAttributes(ctx context.Context, schema tfsdk.Schema) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	checkAttributes(path.Empty(), schema.Attributes, &diags)
+	return diags
+}
+
+func checkAttributes(p path.Path, attrs map[string]tfsdk.Attribute, diags *diag.Diagnostics) {
+	for name, attr := range attrs {
+		ap := p.AtName(name)
+		if !attributeName.MatchString(name) {
+			diags.AddAttributeError(ap, "Invalid schema", fmt.Sprintf("%q is not a valid attribute name", name))
+		}
+		switch {
+		case attr.Type == nil && attr.Attributes == nil:
+			diags.AddAttributeError(ap, "Invalid schema", "attribute must have a type or nested attributes")
+		case attr.Type != nil && attr.Attributes != nil:
+			diags.AddAttributeError(ap, "Invalid schema", "attribute can't have both a type and nested attributes")
+		}
+		switch {
+		case !attr.Required && !attr.Optional && !attr.Computed:
+			diags.AddAttributeError(ap, "Invalid schema", "attribute must be required, optional or computed")
+		case attr.Required && (attr.Optional || attr.Computed):
+			diags.AddAttributeError(ap, "Invalid schema", "required attribute can't be optional or computed")
+		}
+		if attr.Attributes == nil {
+			continue
+		}
+		nested := map[string]tfsdk.Attribute{}
+		for name, a := range attr.Attributes.GetAttributes() {
+			if n, ok := a.(tfsdk.Attribute); ok {
+				nested[name] = n
+			}
+		}
+		checkAttributes(ap, nested, diags)
+	}
+}
+
+// UncoveredFields returns the full names of fields that are neither copied to an attribute nor excluded, including
+// fields of nested messages. excluded maps full message names to their excluded field names.
+func UncoveredFields(md protoreflect.MessageDescriptor, copies []FieldCopy, excluded map[string][]string) []string {
+	copied := make(map[string]FieldCopy, len(copies))
+	for _, c := range copies {
+		copied[c.Field] = c
+	}
+	skip := map[string]bool{}
+	for _, name := range excluded[string(md.FullName())] {
+		skip[name] = true
+	}
+	uncovered := []string{}
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		if skip[string(fd.Name())] {
+			continue
+		}
+		c, ok := copied[string(fd.Name())]
+		if !ok {
+			uncovered = append(uncovered, string(fd.FullName()))
+			continue
+		}
+		nested := fd.Message()
+		if fd.IsMap() {
+			nested = fd.MapValue().Message()
+		}
+		if nested != nil && c.Mapping == nil {
+			uncovered = append(uncovered, UncoveredFields(nested, c.Attributes, excluded)...)
+		}
+	}
+	sort.Strings(uncovered)
+	return uncovered
+}
+
+// Populate sets every copied field of msg to a non-zero value, recursively, so it can be round tripped through the
+// copy functions. Only the first copied field of a oneof is set. Message fields with a type mapping are set by the
+// mapping's Sample, as their messages may only accept specific values, and are left unset if it doesn't have one.
+func Populate(msg protoreflect.Message, copies []FieldCopy) {
+	oneofs := map[protoreflect.FullName]bool{}
+	for _, c := range copies {
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(c.Field))
+		if fd == nil || (c.Mapping != nil && c.Mapping.Sample == nil && fd.Message() != nil) {
+			continue
+		}
+		if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			if oneofs[oneof.FullName()] {
+				continue
+			}
+			oneofs[oneof.FullName()] = true
+		}
+		switch {
+		case fd.IsList():
+			list := msg.Mutable(fd).List()
+			if fd.Message() != nil {
+				v := list.NewElement()
+				populateMessage(v.Message(), c)
+				list.Append(v)
+				continue
+			}
+			list.Append(sampleValue(fd))
+		case fd.IsMap():
+			m := msg.Mutable(fd).Map()
+			key := sampleValue(fd.MapKey()).MapKey()
+			if fd.MapValue().Message() != nil {
+				v := m.NewValue()
+				populateMessage(v.Message(), c)
+				m.Set(key, v)
+				continue
+			}
+			m.Set(key, sampleValue(fd.MapValue()))
+		case fd.Message() != nil:
+			populateMessage(msg.Mutable(fd).Message(), c)
+		default:
+			msg.Set(fd, sampleValue(fd))
+		}
+	}
+}
+
+func populateMessage(msg protoreflect.Message, c FieldCopy) {
+	if c.Mapping != nil {
+		c.Mapping.Sample(msg)
+		return
+	}
+	Populate(msg, c.Attributes)
+}
+
+// sampleValue returns a non-zero value of the field's kind, enums use their first value after the zero value.
+func sampleValue(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString("a")
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte("a"))
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(true)
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		for i := 0; i < values.Len(); i++ {
+			if values.Get(i).Number() != 0 {
+				return protoreflect.ValueOfEnum(values.Get(i).Number())
+			}
+		}
+		return protoreflect.ValueOfEnum(values.Get(0).Number())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(1)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(1)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(1)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(1)
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(1.5)
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(1.5)
+	}
+	return fd.Default()
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSchemaAttributes(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Valid", func(t *testing.T) {
+		schema := tfsdk.Schema{Attributes: map[string]tfsdk.Attribute{
+			"name": {Type: types.StringType, Required: true},
+			"nested": {Optional: true, Computed: true, Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+				"value": {Type: types.Int64Type, Optional: true},
+			})},
+		}}
+		require.False(t, CheckSchemaAttributes(ctx, schema).HasError())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		schema := tfsdk.Schema{Attributes: map[string]tfsdk.Attribute{
+			"Name": {Type: types.StringType, Optional: true},
+			"nested": {Optional: true, Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"neither": {Optional: true},
+				"both":    {Type: types.StringType, Required: true, Computed: true},
+			})},
+		}}
+		errors := map[string]string{}
+		for _, d := range CheckSchemaAttributes(ctx, schema) {
+			errors[d.(interface{ Path() path.Path }).Path().String()] = d.Detail()
+		}
+		require.Equal(t, map[string]string{
+			"Name":           `"Name" is not a valid attribute name`,
+			"nested.neither": "attribute must have a type or nested attributes",
+			"nested.both":    "required attribute can't be optional or computed",
+		}, errors)
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// JSONMapping converts messages to and from a JSON encoded string using their protojson encoding. It is used for
+// google.protobuf.Struct, Value and ListValue, and for messages opted in with jsonMessages. Strings that only differ
+// in formatting, key order or the encoding of equal numbers are semantically equal.
+var JSONMapping = &TypeMapping{
+	Type: types.StringType,
+	ToTerraform: func(ctx context.Context, msg protoreflect.Message) (attr.Value, diag.Diagnostics) {
+		diags := diag.Diagnostics{}
+		b, err := protojson.Marshal(msg.Interface())
+		if err != nil {
+			diags.AddError("Unable to encode JSON", err.Error())
+			return types.String{Null: true}, diags
+		}
+		// protojson randomly adds whitespace to stop its output being relied on, compact it so it is stable.
+		compacted := &bytes.Buffer{}
+		if err := json.Compact(compacted, b); err != nil {
+			diags.AddError("Unable to encode JSON", err.Error())
+			return types.String{Null: true}, diags
+		}
+		return types.String{Value: compacted.String()}, diags
+	},
+	FromTerraform: func(ctx context.Context, value attr.Value, msg protoreflect.Message) diag.Diagnostics {
+		diags := diag.Diagnostics{}
+		s, ok := value.(types.String)
+		if !ok {
+			diags.AddError("Unable to decode JSON", "expected a types.String")
+			return diags
+		}
+		if err := protojson.Unmarshal([]byte(s.Value), msg.Interface()); err != nil {
+			diags.AddError("Unable to decode JSON", err.Error())
+		}
+		return diags
+	},
+	SemanticallyEqual: jsonSemanticallyEqual,
+	Sample: func(msg protoreflect.Message) {
+		jsonSample(msg, map[protoreflect.FullName]bool{})
+	},
+}
+
+// jsonSample sets every field of msg to a non-zero value, recursively, skipping messages already being populated so
+// recursive messages terminate. Only the first field of a oneof is set. Any holds a null google.protobuf.Value, as its
+// @type must be resolvable to be encoded.
+func jsonSample(msg protoreflect.Message, visiting map[protoreflect.FullName]bool) {
+	md := msg.Descriptor()
+	if md.FullName() == "google.protobuf.Any" {
+		packed, _ := anypb.New(structpb.NewNullValue())
+		msg.Set(md.Fields().ByName("type_url"), protoreflect.ValueOfString(packed.TypeUrl))
+		msg.Set(md.Fields().ByName("value"), protoreflect.ValueOfBytes(packed.Value))
+		return
+	}
+	visiting[md.FullName()] = true
+	defer delete(visiting, md.FullName())
+	nested := func(md protoreflect.MessageDescriptor) bool {
+		return md != nil && !visiting[md.FullName()]
+	}
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() && msg.WhichOneof(oneof) != nil {
+			continue
+		}
+		switch {
+		case fd.IsList():
+			list := msg.Mutable(fd).List()
+			if fd.Message() == nil {
+				list.Append(sampleValue(fd))
+			} else if nested(fd.Message()) {
+				v := list.NewElement()
+				jsonSample(v.Message(), visiting)
+				list.Append(v)
+			}
+		case fd.IsMap():
+			m := msg.Mutable(fd).Map()
+			key := sampleValue(fd.MapKey()).MapKey()
+			if fd.MapValue().Message() == nil {
+				m.Set(key, sampleValue(fd.MapValue()))
+			} else if nested(fd.MapValue().Message()) {
+				v := m.NewValue()
+				jsonSample(v.Message(), visiting)
+				m.Set(key, v)
+			}
+		case fd.Message() != nil:
+			if nested(fd.Message()) {
+				jsonSample(msg.Mutable(fd).Message(), visiting)
+			}
+		default:
+			msg.Set(fd, sampleValue(fd))
+		}
+	}
+}
+
+// JSONEqual returns whether a and b are valid JSON encodings of the same value.
+func JSONEqual(a, b string) bool {
+	var av, bv interface{}
+	if err := json.Unmarshal([]byte(a), &av); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &bv); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
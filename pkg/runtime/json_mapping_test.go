@@ -0,0 +1,70 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestJSONEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{name: "Formatting", a: `{"a": [1, 2]}`, b: "{\n  \"a\": [1,2]\n}", want: true},
+		{name: "Key order", a: `{"a":1,"b":2}`, b: `{"b":2,"a":1}`, want: true},
+		{name: "Number encoding", a: `{"a":1}`, b: `{"a":1.0}`, want: true},
+		{name: "Different values", a: `{"a":1}`, b: `{"a":"1"}`, want: false},
+		{name: "Array order", a: `[1,2]`, b: `[2,1]`, want: false},
+		{name: "Invalid", a: `{`, b: `{`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, JSONEqual(tt.a, tt.b))
+		})
+	}
+}
+
+func TestJSONMappingSample(t *testing.T) {
+	ctx := context.Background()
+	for _, msg := range []proto.Message{
+		&structpb.Struct{},
+		&structpb.Value{},
+		&structpb.ListValue{},
+		&anypb.Any{},
+		// DescriptorProto is recursive through nested_type.
+		&descriptorpb.DescriptorProto{},
+	} {
+		t.Run(string(msg.ProtoReflect().Descriptor().FullName()), func(t *testing.T) {
+			want := msg.ProtoReflect()
+			JSONMapping.Sample(want)
+			require.NotZero(t, proto.Size(want.Interface()))
+
+			value, diags := JSONMapping.ToTerraform(ctx, want)
+			require.False(t, diags.HasError(), diags)
+			got := want.Type().New()
+			require.False(t, JSONMapping.FromTerraform(ctx, value, got).HasError())
+			require.True(t, proto.Equal(want.Interface(), got.Interface()), got)
+		})
+	}
+}
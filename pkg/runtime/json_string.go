@@ -0,0 +1,90 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ xattr.TypeWithValidate = JSONStringType{}
+
+// JSONStringType is a string type that only accepts valid JSON, used for string fields listed in jsonStrings. Its
+// values are types.String.
+type JSONStringType struct{}
+
+// JSONStringMapping is the FieldCopy mapping of string fields listed in jsonStrings. Only its SemanticallyEqual is
+// used: when copying to Terraform, a string that is the same JSON as the prior value keeps the prior value.
+var JSONStringMapping = &TypeMapping{
+	Type:              JSONStringType{},
+	SemanticallyEqual: jsonSemanticallyEqual,
+}
+
+func (t JSONStringType) TerraformType(ctx context.Context) tftypes.Type {
+	return types.StringType.TerraformType(ctx)
+}
+
+func (t JSONStringType) ValueFromTerraform(ctx context.Context, value tftypes.Value) (attr.Value, error) {
+	return types.StringType.ValueFromTerraform(ctx, value)
+}
+
+func (t JSONStringType) ValueType(ctx context.Context) attr.Value {
+	return types.StringType.ValueType(ctx)
+}
+
+func (t JSONStringType) Equal(o attr.Type) bool {
+	_, ok := o.(JSONStringType)
+	return ok
+}
+
+func (t JSONStringType) String() string {
+	return "JSONStringType"
+}
+
+func (t JSONStringType) ApplyTerraform5AttributePathStep(step tftypes.AttributePathStep) (interface{}, error) {
+	return nil, fmt.Errorf("cannot apply AttributePathStep %T to %s", step, t)
+}
+
+// Validate rejects strings that aren't valid JSON.
+func (t JSONStringType) Validate(ctx context.Context, value tftypes.Value, p path.Path) diag.Diagnostics {
+	diags := types.StringType.Validate(ctx, value, p)
+	if diags.HasError() || value.IsNull() || !value.IsKnown() {
+		return diags
+	}
+	var s string
+	if err := value.As(&s); err != nil {
+		diags.AddAttributeError(p, "Invalid JSON", err.Error())
+		return diags
+	}
+	if !json.Valid([]byte(s)) {
+		diags.AddAttributeError(p, "Invalid JSON", fmt.Sprintf("%q is not valid JSON", s))
+	}
+	return diags
+}
+
+// jsonSemanticallyEqual returns whether prior and value are strings holding the same JSON.
+func jsonSemanticallyEqual(ctx context.Context, prior, value attr.Value) bool {
+	a, aok := prior.(types.String)
+	b, bok := value.(types.String)
+	return aok && bok && JSONEqual(a.Value, b.Value)
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+// OperationGetter gets the latest state of a long-running operation, longrunning.OperationsClient implements it.
+type OperationGetter interface {
+	GetOperation(ctx context.Context, in *longrunning.GetOperationRequest, opts ...grpc.CallOption) (*longrunning.Operation, error)
+}
+
+var (
+	// PollInterval is how long to wait before first polling an operation, it doubles after every poll.
+	PollInterval = time.Second
+	// MaxPollInterval is the longest to wait between polls.
+	MaxPollInterval = 30 * time.Second
+)
+
+// WaitOperation polls op until it is done or timeout elapses. If response is not nil the operation's response is
+// unpacked into it. A timeout of zero waits until ctx is done.
+func WaitOperation(ctx context.Context, client OperationGetter, op *longrunning.Operation, timeout time.Duration, response proto.Message) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	name := op.GetName()
+	interval := PollInterval
+	for !op.GetDone() {
+		select {
+		case <-ctx.Done():
+			diags.AddError("Operation timed out", fmt.Sprintf("Operation %s did not complete: %v", name, ctx.Err()))
+			return diags
+		case <-time.After(interval):
+		}
+
+		var err error
+		op, err = client.GetOperation(ctx, &longrunning.GetOperationRequest{Name: name})
+		if err != nil {
+			diags.AddError("Unable to poll operation", fmt.Sprintf("Unable to get operation %s: %v", name, err))
+			return diags
+		}
+		if interval *= 2; interval > MaxPollInterval {
+			interval = MaxPollInterval
+		}
+	}
+
+	if status := op.GetError(); status != nil {
+		diags.AddError("Operation failed", fmt.Sprintf("Operation %s failed with %s: %s", name, codes.Code(status.GetCode()), status.GetMessage()))
+		return diags
+	}
+	if response != nil && op.GetResponse() != nil {
+		if err := op.GetResponse().UnmarshalTo(response); err != nil {
+			diags.AddError("Unable to unpack operation response", fmt.Sprintf("Unable to unpack the response of operation %s: %v", name, err))
+		}
+	}
+	return diags
+}
@@ -0,0 +1,48 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// DefaultValue returns a plan modifier that sets the attribute to value when it isn't set in the config.
+// The attribute must be both optional and computed.
+func DefaultValue(value attr.Value) tfsdk.AttributePlanModifier {
+	return defaultValueModifier{value: value}
+}
+
+type defaultValueModifier struct {
+	value attr.Value
+}
+
+func (m defaultValueModifier) Modify(ctx context.Context, req tfsdk.ModifyAttributePlanRequest, resp *tfsdk.ModifyAttributePlanResponse) {
+	if req.AttributeConfig == nil || !req.AttributeConfig.IsNull() {
+		return
+	}
+	resp.AttributePlan = m.value
+}
+
+func (m defaultValueModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("Defaults to %s if not set.", m.value)
+}
+
+func (m defaultValueModifier) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("Defaults to `%s` if not set.", m.value)
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// ParseResourceName parses an AIP resource name against its pattern, e.g. projects/{project}/widgets/{widget},
+// returning the value of each variable in the pattern.
+func ParseResourceName(pattern, name string) (map[string]string, error) {
+	patternSegments := strings.Split(pattern, "/")
+	nameSegments := strings.Split(name, "/")
+	if len(patternSegments) != len(nameSegments) {
+		return nil, fmt.Errorf("resource name %q does not match pattern %q", name, pattern)
+	}
+
+	values := map[string]string{}
+	for i, segment := range patternSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			if nameSegments[i] == "" {
+				return nil, fmt.Errorf("resource name %q has an empty %s", name, segment)
+			}
+			values[strings.Trim(segment, "{}")] = nameSegments[i]
+			continue
+		}
+		if segment != nameSegments[i] {
+			return nil, fmt.Errorf("resource name %q does not match pattern %q", name, pattern)
+		}
+	}
+	return values, nil
+}
+
+// ParseImportID parses an import ID that is either a full resource name or the short form made up of just the
+// variables in the pattern, e.g. {project}/{widget} for projects/{project}/widgets/{widget}.
+func ParseImportID(pattern, id string) (map[string]string, error) {
+	if values, err := ParseResourceName(pattern, id); err == nil {
+		return values, nil
+	}
+
+	variables := []string{}
+	for _, segment := range strings.Split(pattern, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			variables = append(variables, segment)
+		}
+	}
+	short := strings.Join(variables, "/")
+	values, err := ParseResourceName(short, id)
+	if err != nil {
+		return nil, fmt.Errorf("import ID %q must be either a full resource name, %s, or %s", id, pattern, short)
+	}
+	return values, nil
+}
+
+// ResourceNameSegments returns the values of the name segment attributes of a Terraform object, e.g. plan.Raw, keyed
+// by variable. It returns false unless every segment is known, non-null and non-empty.
+func ResourceNameSegments(tf tftypes.Value, variables ...string) (map[string]string, bool) {
+	attrs := map[string]tftypes.Value{}
+	if tf.IsNull() || !tf.IsKnown() || tf.As(&attrs) != nil {
+		return nil, false
+	}
+	values := map[string]string{}
+	for _, v := range variables {
+		attr, ok := attrs[v]
+		if !ok || attr.IsNull() || !attr.IsKnown() {
+			return nil, false
+		}
+		var s string
+		if err := attr.As(&s); err != nil || s == "" {
+			return nil, false
+		}
+		values[v] = s
+	}
+	return values, true
+}
+
+// SetStringAttributes sets top level string attributes of a Terraform object, e.g. state.Raw, such as a resource's
+// id and name segments.
+func SetStringAttributes(tf *tftypes.Value, values map[string]string) error {
+	attrs := map[string]tftypes.Value{}
+	if err := tf.As(&attrs); err != nil {
+		return err
+	}
+	for name, value := range values {
+		if _, ok := attrs[name]; !ok {
+			return fmt.Errorf("%s has no %s attribute", tf.Type(), name)
+		}
+		attrs[name] = tftypes.NewValue(tftypes.String, value)
+	}
+	*tf = tftypes.NewValue(tf.Type(), attrs)
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResourceName(t *testing.T) {
+	pattern := "projects/{project}/widgets/{widget}"
+
+	values, err := ParseResourceName(pattern, "projects/p1/widgets/w1")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"project": "p1", "widget": "w1"}, values)
+
+	_, err = ParseResourceName(pattern, "projects/p1/gadgets/w1")
+	require.EqualError(t, err, `resource name "projects/p1/gadgets/w1" does not match pattern "projects/{project}/widgets/{widget}"`)
+
+	_, err = ParseResourceName(pattern, "projects/p1/widgets")
+	require.Error(t, err)
+
+	_, err = ParseResourceName(pattern, "projects//widgets/w1")
+	require.EqualError(t, err, `resource name "projects//widgets/w1" has an empty {project}`)
+}
+
+func TestParseImportID(t *testing.T) {
+	pattern := "projects/{project}/widgets/{widget}"
+	expected := map[string]string{"project": "p1", "widget": "w1"}
+
+	values, err := ParseImportID(pattern, "projects/p1/widgets/w1")
+	require.NoError(t, err)
+	require.Equal(t, expected, values)
+
+	values, err = ParseImportID(pattern, "p1/w1")
+	require.NoError(t, err)
+	require.Equal(t, expected, values)
+
+	_, err = ParseImportID(pattern, "p1")
+	require.EqualError(t, err, `import ID "p1" must be either a full resource name, projects/{project}/widgets/{widget}, or {project}/{widget}`)
+}
+
+func TestResourceNameSegments(t *testing.T) {
+	typ := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"project": tftypes.String, "widget": tftypes.String}}
+
+	values, ok := ResourceNameSegments(tftypes.NewValue(typ, map[string]tftypes.Value{
+		"project": tftypes.NewValue(tftypes.String, "p1"),
+		"widget":  tftypes.NewValue(tftypes.String, "w1"),
+	}), "project", "widget")
+	require.True(t, ok)
+	require.Equal(t, map[string]string{"project": "p1", "widget": "w1"}, values)
+
+	_, ok = ResourceNameSegments(tftypes.NewValue(typ, map[string]tftypes.Value{
+		"project": tftypes.NewValue(tftypes.String, "p1"),
+		"widget":  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	}), "project", "widget")
+	require.False(t, ok)
+
+	_, ok = ResourceNameSegments(tftypes.NewValue(typ, nil), "project", "widget")
+	require.False(t, ok)
+}
+
+func TestSetStringAttributes(t *testing.T) {
+	typ := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.String, "size": tftypes.Number}}
+	tf := tftypes.NewValue(typ, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"size": tftypes.NewValue(tftypes.Number, nil),
+	})
+	require.NoError(t, SetStringAttributes(&tf, map[string]string{"id": "projects/p1"}))
+	require.Equal(t, tftypes.NewValue(typ, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "projects/p1"),
+		"size": tftypes.NewValue(tftypes.Number, nil),
+	}), tf)
+
+	require.EqualError(t, SetStringAttributes(&tf, map[string]string{"name": "n"}), `tftypes.Object["id":tftypes.String, "size":tftypes.Number] has no name attribute`)
+}
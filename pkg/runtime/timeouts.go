@@ -0,0 +1,53 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DefaultTimeout is used for operations without a timeout set.
+var DefaultTimeout = 20 * time.Minute
+
+// AttributeGetter is implemented by tfsdk.Config, tfsdk.Plan and tfsdk.State.
+type AttributeGetter interface {
+	GetAttribute(ctx context.Context, path path.Path, target interface{}) diag.Diagnostics
+}
+
+// Timeout returns the duration set for operation (create, read, update or delete) in the timeouts attribute,
+// or def if it isn't set. A nil getter always returns def.
+func Timeout(ctx context.Context, getter AttributeGetter, operation string, def time.Duration) (time.Duration, diag.Diagnostics) {
+	if getter == nil {
+		return def, nil
+	}
+	p := path.Root("timeouts").AtName(operation)
+	var value types.String
+	diags := getter.GetAttribute(ctx, p, &value)
+	if diags.HasError() || value.Null || value.Unknown {
+		return def, diags
+	}
+	timeout, err := time.ParseDuration(value.Value)
+	if err != nil {
+		diags.AddAttributeError(p, "Invalid timeout", fmt.Sprintf("%q is not a valid duration, e.g. 30s or 2h45m: %v", value.Value, err))
+		return def, diags
+	}
+	return timeout, diags
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TimestampMapping converts google.protobuf.Timestamp to and from an RFC 3339 string in UTC, e.g.
+// "2022-10-19T15:04:05.5Z". Strings for the same instant, e.g. with another offset or trailing zeros in the fraction,
+// are semantically equal.
+var TimestampMapping = &TypeMapping{
+	Type: types.StringType,
+	ToTerraform: func(ctx context.Context, msg protoreflect.Message) (attr.Value, diag.Diagnostics) {
+		diags := diag.Diagnostics{}
+		fields := msg.Descriptor().Fields()
+		ts := &timestamppb.Timestamp{
+			Seconds: msg.Get(fields.ByName("seconds")).Int(),
+			Nanos:   int32(msg.Get(fields.ByName("nanos")).Int()),
+		}
+		if err := ts.CheckValid(); err != nil {
+			diags.AddError("Invalid timestamp", err.Error())
+			return types.String{Null: true}, diags
+		}
+		return types.String{Value: ts.AsTime().Format(time.RFC3339Nano)}, diags
+	},
+	FromTerraform: func(ctx context.Context, value attr.Value, msg protoreflect.Message) diag.Diagnostics {
+		diags := diag.Diagnostics{}
+		s, ok := value.(types.String)
+		if !ok {
+			diags.AddError("Invalid timestamp", "expected a types.String")
+			return diags
+		}
+		t, err := time.Parse(time.RFC3339Nano, s.Value)
+		if err != nil {
+			diags.AddError("Invalid timestamp", fmt.Sprintf("%q is not an RFC 3339 timestamp, e.g. 2022-10-19T15:04:05Z", s.Value))
+			return diags
+		}
+		ts := timestamppb.New(t)
+		if err := ts.CheckValid(); err != nil {
+			diags.AddError("Invalid timestamp", err.Error())
+			return diags
+		}
+		fields := msg.Descriptor().Fields()
+		msg.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(ts.Seconds))
+		msg.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(ts.Nanos))
+		return diags
+	},
+	SemanticallyEqual: func(ctx context.Context, prior, value attr.Value) bool {
+		a, aok := prior.(types.String)
+		b, bok := value.(types.String)
+		if !aok || !bok {
+			return false
+		}
+		at, aerr := time.Parse(time.RFC3339Nano, a.Value)
+		bt, berr := time.Parse(time.RFC3339Nano, b.Value)
+		return aerr == nil && berr == nil && at.Equal(bt)
+	},
+	Sample: func(msg protoreflect.Message) {
+		fields := msg.Descriptor().Fields()
+		msg.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(time.Date(2022, 10, 19, 15, 4, 5, 0, time.UTC).Unix()))
+		msg.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(500000000))
+	},
+}
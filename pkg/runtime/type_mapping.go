@@ -0,0 +1,76 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TypeMapping converts a message to and from a single attribute of Type, instead of nested attributes.
+type TypeMapping struct {
+	Type          attr.Type
+	ToTerraform   func(ctx context.Context, msg protoreflect.Message) (attr.Value, diag.Diagnostics)
+	FromTerraform func(ctx context.Context, value attr.Value, msg protoreflect.Message) diag.Diagnostics
+	// SemanticallyEqual is optional, if it returns true the prior value is kept instead of an equivalent new value so
+	// differences that don't matter, e.g. formatting, don't show up as diffs.
+	SemanticallyEqual func(ctx context.Context, prior, value attr.Value) bool
+	// Sample is optional, it sets msg to a value that round trips through the mapping. The generated tests use it to
+	// populate fields with the mapping, they are left unset without it.
+	Sample func(msg protoreflect.Message)
+}
+
+// MapType returns a TypeMapping from typed conversion functions, e.g.
+//
+//	func DateToTerraform(ctx context.Context, obj *date.Date) (types.String, diag.Diagnostics)
+//	func DateFromTerraform(ctx context.Context, value types.String, obj *date.Date) diag.Diagnostics
+//
+// FromTerraform is only called with known, non-null values and sets the fields of an empty obj. An optional sample
+// function sets the fields of an empty obj for the generated tests, e.g.
+//
+//	func DateSample(obj *date.Date)
+func MapType[M proto.Message, V attr.Value](
+	typ attr.Type,
+	to func(context.Context, M) (V, diag.Diagnostics),
+	from func(context.Context, V, M) diag.Diagnostics,
+	sample ...func(M),
+) *TypeMapping {
+	mapping := &TypeMapping{
+		Type: typ,
+		ToTerraform: func(ctx context.Context, msg protoreflect.Message) (attr.Value, diag.Diagnostics) {
+			return to(ctx, msg.Interface().(M))
+		},
+		FromTerraform: func(ctx context.Context, value attr.Value, msg protoreflect.Message) diag.Diagnostics {
+			v, ok := value.(V)
+			if !ok {
+				diags := diag.Diagnostics{}
+				diags.AddError("Unable to copy from Terraform", fmt.Sprintf("expected a %T, got a %T", v, value))
+				return diags
+			}
+			return from(ctx, v, msg.Interface().(M))
+		},
+	}
+	if len(sample) > 0 {
+		mapping.Sample = func(msg protoreflect.Message) {
+			sample[0](msg.Interface().(M))
+		}
+	}
+	return mapping
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// UpdateMaskPath maps an attribute to the path of the proto field it was generated from.
+type UpdateMaskPath struct {
+	Attribute path.Path
+	Field     string
+	// Fields are the paths of a singular message field's fields. They are compared individually
+	// unless the message itself is being set or cleared, in which case Field is used.
+	Fields []UpdateMaskPath
+}
+
+// UpdateMask returns a field mask of every proto field whose attribute differs between state and plan.
+func UpdateMask(ctx context.Context, plan tfsdk.Plan, state tfsdk.State, paths []UpdateMaskPath) (*fieldmaskpb.FieldMask, diag.Diagnostics) {
+	mask := &fieldmaskpb.FieldMask{}
+	diags := diag.Diagnostics{}
+	for _, p := range paths {
+		diags.Append(p.appendChanged(ctx, plan, state, mask)...)
+	}
+	return mask, diags
+}
+
+func (p UpdateMaskPath) appendChanged(ctx context.Context, plan tfsdk.Plan, state tfsdk.State, mask *fieldmaskpb.FieldMask) diag.Diagnostics {
+	var planned, current attr.Value
+	diags := plan.GetAttribute(ctx, p.Attribute, &planned)
+	diags.Append(state.GetAttribute(ctx, p.Attribute, &current)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if len(p.Fields) == 0 || planned.IsUnknown() || planned.IsNull() != current.IsNull() {
+		if !planned.Equal(current) {
+			mask.Paths = append(mask.Paths, p.Field)
+		}
+		return diags
+	}
+	if planned.IsNull() {
+		return diags
+	}
+	for _, field := range p.Fields {
+		diags.Append(field.appendChanged(ctx, plan, state, mask)...)
+	}
+	return diags
+}
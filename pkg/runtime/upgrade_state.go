@@ -0,0 +1,177 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// StateUpgrade maps an attribute of the current schema to the attribute of the prior schema it is upgraded from.
+type StateUpgrade struct {
+	Attribute string
+	Prior     string
+	// Enum maps enum numbers to names when an enum is upgraded from its number to its name.
+	Enum map[int64]string
+	// Attributes maps nested attributes. If it is empty, object attributes are matched by name.
+	Attributes []StateUpgrade
+}
+
+// UpgradeState sets the response state to the prior state upgraded to the current schema. Attributes of the current
+// schema that aren't upgraded from a prior attribute are null. The StateUpgrader must have a PriorSchema.
+func UpgradeState(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse, upgrades []StateUpgrade) {
+	if req.State == nil {
+		resp.Diagnostics.AddError("Missing prior state", "The state upgrader has no prior schema, unable to read the prior state.")
+		return
+	}
+	value, err := upgradeValue(req.State.Raw, resp.State.Schema.Type().TerraformType(ctx), StateUpgrade{Attributes: upgrades})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to upgrade state", err.Error())
+		return
+	}
+	resp.State.Raw = value
+}
+
+func upgradeValue(prior tftypes.Value, typ tftypes.Type, upgrade StateUpgrade) (tftypes.Value, error) {
+	if prior.IsNull() || !prior.IsKnown() {
+		return tftypes.NewValue(typ, nil), nil
+	}
+
+	switch t := typ.(type) {
+	case tftypes.Object:
+		priorAttrs := map[string]tftypes.Value{}
+		if err := prior.As(&priorAttrs); err != nil {
+			return tftypes.Value{}, err
+		}
+		upgrades := upgrade.Attributes
+		if len(upgrades) == 0 {
+			for name := range t.AttributeTypes {
+				upgrades = append(upgrades, StateUpgrade{Attribute: name, Prior: name})
+			}
+		}
+		attrs := map[string]tftypes.Value{}
+		for name, attrType := range t.AttributeTypes {
+			attrs[name] = tftypes.NewValue(attrType, nil)
+		}
+		for _, u := range upgrades {
+			attrType, ok := t.AttributeTypes[u.Attribute]
+			priorAttr, priorOk := priorAttrs[u.Prior]
+			if !ok || !priorOk {
+				continue
+			}
+			value, err := upgradeValue(priorAttr, attrType, u)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("%s: %w", u.Attribute, err)
+			}
+			attrs[u.Attribute] = value
+		}
+		return tftypes.NewValue(t, attrs), nil
+
+	case tftypes.List, tftypes.Set:
+		elemType := elementType(t)
+		priorElems := []tftypes.Value{}
+		if err := prior.As(&priorElems); err != nil {
+			return tftypes.Value{}, err
+		}
+		elems := make([]tftypes.Value, 0, len(priorElems))
+		for i, elem := range priorElems {
+			value, err := upgradeValue(elem, elemType, upgrade)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("[%d]: %w", i, err)
+			}
+			elems = append(elems, value)
+		}
+		return tftypes.NewValue(t, elems), nil
+
+	case tftypes.Map:
+		priorElems := map[string]tftypes.Value{}
+		if err := prior.As(&priorElems); err != nil {
+			return tftypes.Value{}, err
+		}
+		elems := make(map[string]tftypes.Value, len(priorElems))
+		for key, elem := range priorElems {
+			value, err := upgradeValue(elem, t.ElementType, upgrade)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("[%q]: %w", key, err)
+			}
+			elems[key] = value
+		}
+		return tftypes.NewValue(t, elems), nil
+	}
+
+	if prior.Type().Equal(typ) {
+		return prior, nil
+	}
+	return convertPrimitive(prior, typ, upgrade.Enum)
+}
+
+func elementType(typ tftypes.Type) tftypes.Type {
+	if list, ok := typ.(tftypes.List); ok {
+		return list.ElementType
+	}
+	return typ.(tftypes.Set).ElementType
+}
+
+// convertPrimitive converts between strings, numbers and bools, e.g. when an enum changes from its number to its name.
+func convertPrimitive(prior tftypes.Value, typ tftypes.Type, enum map[int64]string) (tftypes.Value, error) {
+	switch {
+	case prior.Type().Is(tftypes.Number) && typ.Is(tftypes.String):
+		var n big.Float
+		if err := prior.As(&n); err != nil {
+			return tftypes.Value{}, err
+		}
+		if i, accuracy := n.Int64(); accuracy == big.Exact {
+			if name, ok := enum[i]; ok {
+				return tftypes.NewValue(typ, name), nil
+			}
+		}
+		return tftypes.NewValue(typ, n.Text('f', -1)), nil
+
+	case prior.Type().Is(tftypes.Bool) && typ.Is(tftypes.String):
+		var b bool
+		if err := prior.As(&b); err != nil {
+			return tftypes.Value{}, err
+		}
+		return tftypes.NewValue(typ, strconv.FormatBool(b)), nil
+
+	case prior.Type().Is(tftypes.String) && typ.Is(tftypes.Number):
+		var s string
+		if err := prior.As(&s); err != nil {
+			return tftypes.Value{}, err
+		}
+		n, _, err := big.ParseFloat(s, 10, 512, big.ToNearestEven)
+		if err != nil {
+			return tftypes.Value{}, fmt.Errorf("unable to convert %q to a number: %v", s, err)
+		}
+		return tftypes.NewValue(typ, n), nil
+
+	case prior.Type().Is(tftypes.String) && typ.Is(tftypes.Bool):
+		var s string
+		if err := prior.As(&s); err != nil {
+			return tftypes.Value{}, err
+		}
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return tftypes.Value{}, fmt.Errorf("unable to convert %q to a bool: %v", s, err)
+		}
+		return tftypes.NewValue(typ, b), nil
+	}
+	return tftypes.Value{}, fmt.Errorf("unable to convert %s to %s", prior.Type(), typ)
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		prior   tftypes.Value
+		typ     tftypes.Type
+		upgrade StateUpgrade
+		want    tftypes.Value
+		err     string
+	}{
+		{
+			name:    "Enum number to name",
+			prior:   tftypes.NewValue(tftypes.Number, 2),
+			typ:     tftypes.String,
+			upgrade: StateUpgrade{Enum: map[int64]string{1: "RED", 2: "BLUE"}},
+			want:    tftypes.NewValue(tftypes.String, "BLUE"),
+		},
+		{
+			name:    "Unknown enum number",
+			prior:   tftypes.NewValue(tftypes.Number, 3),
+			typ:     tftypes.String,
+			upgrade: StateUpgrade{Enum: map[int64]string{1: "RED"}},
+			want:    tftypes.NewValue(tftypes.String, "3"),
+		},
+		{
+			name:  "List elements",
+			prior: tftypes.NewValue(tftypes.List{ElementType: tftypes.Bool}, []tftypes.Value{tftypes.NewValue(tftypes.Bool, true)}),
+			typ:   tftypes.List{ElementType: tftypes.String},
+			want:  tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "true")}),
+		},
+		{
+			name: "Objects match by name",
+			prior: tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"a": tftypes.String, "b": tftypes.String}}, map[string]tftypes.Value{
+				"a": tftypes.NewValue(tftypes.String, "x"),
+				"b": tftypes.NewValue(tftypes.String, "y"),
+			}),
+			typ: tftypes.Object{AttributeTypes: map[string]tftypes.Type{"a": tftypes.String, "c": tftypes.String}},
+			want: tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"a": tftypes.String, "c": tftypes.String}}, map[string]tftypes.Value{
+				"a": tftypes.NewValue(tftypes.String, "x"),
+				"c": tftypes.NewValue(tftypes.String, nil),
+			}),
+		},
+		{
+			name:  "Invalid number",
+			prior: tftypes.NewValue(tftypes.String, "ten"),
+			typ:   tftypes.Number,
+			err:   `unable to convert "ten" to a number`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := upgradeValue(tt.prior, tt.typ, tt.upgrade)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tt.want.Equal(got), got.String())
+		})
+	}
+}
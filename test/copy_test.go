@@ -0,0 +1,165 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func presenceState(t *testing.T, ctx context.Context) tfsdk.State {
+	schema, diags := GenSchemaPresence(ctx)
+	require.False(t, diags.HasError())
+	return tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+}
+
+func TestCopyToTerraform(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Unset fields with presence are null", func(t *testing.T) {
+		state := presenceState(t, ctx)
+		require.False(t, CopyPresenceToTerraform(ctx, &Presence{}, &state.Raw).HasError())
+
+		for _, name := range []string{"optional_bool", "optional_int64", "optional_string", "optional_mode", "message", "list", "map", "left", "right"} {
+			var value attr.Value
+			require.False(t, state.GetAttribute(ctx, path.Root(name), &value).HasError())
+			require.True(t, value.IsNull(), name)
+		}
+	})
+
+	t.Run("Zero values without presence keep a null prior", func(t *testing.T) {
+		state := presenceState(t, ctx)
+		require.False(t, CopyPresenceToTerraform(ctx, &Presence{}, &state.Raw).HasError())
+
+		var value attr.Value
+		require.False(t, state.GetAttribute(ctx, path.Root("bool"), &value).HasError())
+		require.True(t, value.IsNull())
+
+		require.False(t, state.SetAttribute(ctx, path.Root("bool"), false).HasError())
+		require.False(t, CopyPresenceToTerraform(ctx, &Presence{}, &state.Raw).HasError())
+		var b types.Bool
+		require.False(t, state.GetAttribute(ctx, path.Root("bool"), &b).HasError())
+		require.Equal(t, types.Bool{Value: false}, b)
+	})
+
+	t.Run("Null scalars and empty lists round trip", func(t *testing.T) {
+		state := presenceState(t, ctx)
+		listType, diags := state.Schema.TypeAtPath(ctx, path.Root("list"))
+		require.False(t, diags.HasError())
+		require.False(t, state.SetAttribute(ctx, path.Root("list"), types.List{ElemType: listType.(types.ListType).ElemType, Elems: []attr.Value{}}).HasError())
+		require.False(t, state.SetAttribute(ctx, path.Root("map"), types.Map{ElemType: types.Int64Type, Elems: map[string]attr.Value{}}).HasError())
+		require.False(t, state.SetAttribute(ctx, path.Root("float"), 0.0).HasError())
+		prior := state.Raw.Copy()
+
+		obj := &Presence{}
+		require.False(t, CopyPresenceFromTerraform(ctx, state.Raw, obj).HasError())
+		require.False(t, CopyPresenceToTerraform(ctx, obj, &state.Raw).HasError())
+		require.True(t, prior.Equal(state.Raw), state.Raw.String())
+	})
+
+	t.Run("Zero values that are set aren't null", func(t *testing.T) {
+		state := presenceState(t, ctx)
+		obj := &Presence{
+			OptionalBool:   proto.Bool(false),
+			OptionalInt64:  proto.Int64(0),
+			OptionalString: proto.String(""),
+			Message:        &OtherNested{},
+			Choice:         &Presence_Left{},
+		}
+		require.False(t, CopyPresenceToTerraform(ctx, obj, &state.Raw).HasError())
+
+		var b types.Bool
+		require.False(t, state.GetAttribute(ctx, path.Root("optional_bool"), &b).HasError())
+		require.Equal(t, types.Bool{Value: false}, b)
+		var i types.Int64
+		require.False(t, state.GetAttribute(ctx, path.Root("optional_int64"), &i).HasError())
+		require.Equal(t, types.Int64{Value: 0}, i)
+		var s types.String
+		require.False(t, state.GetAttribute(ctx, path.Root("left"), &s).HasError())
+		require.Equal(t, types.String{Value: ""}, s)
+		var message attr.Value
+		require.False(t, state.GetAttribute(ctx, path.Root("message"), &message).HasError())
+		require.False(t, message.IsNull())
+	})
+
+	t.Run("Float keeps its precision", func(t *testing.T) {
+		state := presenceState(t, ctx)
+		require.False(t, CopyPresenceToTerraform(ctx, &Presence{Float: 0.1}, &state.Raw).HasError())
+
+		var f types.Float64
+		require.False(t, state.GetAttribute(ctx, path.Root("float"), &f).HasError())
+		require.Equal(t, 0.1, f.Value)
+	})
+
+	t.Run("Attributes that aren't fields are kept", func(t *testing.T) {
+		schema, diags := GenSchemaWidget(ctx)
+		require.False(t, diags.HasError())
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		require.False(t, state.SetAttribute(ctx, path.Root("id"), "projects/p1/widgets/w1").HasError())
+
+		require.False(t, CopyWidgetToTerraform(ctx, &Widget{DisplayName: "w"}, &state.Raw).HasError())
+
+		var s types.String
+		require.False(t, state.GetAttribute(ctx, path.Root("id"), &s).HasError())
+		require.Equal(t, "projects/p1/widgets/w1", s.Value)
+		require.False(t, state.GetAttribute(ctx, path.Root("display_name"), &s).HasError())
+		require.Equal(t, "w", s.Value)
+	})
+}
+
+func TestCopyFromTerraform(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Only known, non-null attributes are set", func(t *testing.T) {
+		state := presenceState(t, ctx)
+		require.False(t, state.SetAttribute(ctx, path.Root("optional_bool"), false).HasError())
+		require.False(t, state.SetAttribute(ctx, path.Root("right"), 7).HasError())
+		require.False(t, state.SetAttribute(ctx, path.Root("optional_int64"), types.Int64{Unknown: true}).HasError())
+
+		obj := &Presence{OptionalString: proto.String("stale")}
+		require.False(t, CopyPresenceFromTerraform(ctx, state.Raw, obj).HasError())
+		require.True(t, proto.Equal(&Presence{OptionalBool: proto.Bool(false), Choice: &Presence_Right{Right: 7}}, obj), obj)
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		mode := Mode_ON
+		want := &Presence{
+			OptionalBool:   proto.Bool(true),
+			OptionalInt64:  proto.Int64(42),
+			OptionalString: proto.String("s"),
+			OptionalMode:   &mode,
+			Bool:           true,
+			Float:          1.5,
+			Message:        &OtherNested{Str: "m"},
+			List:           []*OtherNested{{Str: "a"}, {}},
+			Map:            map[string]int32{"a": 1},
+			Choice:         &Presence_Left{Left: "l"},
+		}
+		state := presenceState(t, ctx)
+		require.False(t, CopyPresenceToTerraform(ctx, want, &state.Raw).HasError())
+
+		got := &Presence{}
+		require.False(t, CopyPresenceFromTerraform(ctx, state.Raw, got).HasError())
+		require.True(t, proto.Equal(want, got), got)
+	})
+}
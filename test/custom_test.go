@@ -0,0 +1,150 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/liamawhite/protoc-gen-terraform/pkg/runtime"
+)
+
+func TestCustomTypes(t *testing.T) {
+	ctx := context.Background()
+	schema, diags := GenSchemaDocument(ctx)
+	require.False(t, diags.HasError())
+	mode := runtime.EnumType{Name: "test.Mode", Values: []string{"UNKNOWN", "ON", "OFF"}}
+
+	t.Run("Schema", func(t *testing.T) {
+		require.Equal(t, JSONType{}, schema.Attributes["body"].Type)
+		require.Equal(t, mode, schema.Attributes["mode"].Type)
+		require.Equal(t, types.ListType{ElemType: mode}, schema.Attributes["modes"].Type)
+		require.Equal(t, types.MapType{ElemType: mode}, schema.Attributes["by_name"].Type)
+		require.Equal(t, runtime.JSONStringType{}, schema.Attributes["metadata"].Type)
+		require.Equal(t, types.StringType, schema.Attributes["update_time"].Type)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		validate := func(name string, value tftypes.Value) []string {
+			details := []string{}
+			for _, d := range schema.Attributes[name].Type.(xattr.TypeWithValidate).Validate(ctx, value, path.Root(name)) {
+				details = append(details, d.Detail())
+			}
+			return details
+		}
+		require.Empty(t, validate("body", tftypes.NewValue(tftypes.String, `{"a": 1}`)))
+		require.Equal(t, []string{`"{" is not valid JSON`}, validate("body", tftypes.NewValue(tftypes.String, "{")))
+		require.Empty(t, validate("metadata", tftypes.NewValue(tftypes.String, `[1, 2]`)))
+		require.Equal(t, []string{`"]" is not valid JSON`}, validate("metadata", tftypes.NewValue(tftypes.String, "]")))
+		require.Empty(t, validate("mode", tftypes.NewValue(tftypes.String, "OFF")))
+		require.Empty(t, validate("mode", tftypes.NewValue(tftypes.String, tftypes.UnknownValue)))
+		require.Equal(t, []string{`"DIM" is not a test.Mode, must be one of "UNKNOWN", "ON", "OFF"`},
+			validate("modes", tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "ON"),
+				tftypes.NewValue(tftypes.String, "DIM"),
+			})))
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		want := &Document{
+			Body:   `{"a": 1}`,
+			Mode:   Mode_OFF,
+			Modes:  []Mode{Mode_ON},
+			ByName: map[string]Mode{"a": Mode_ON},
+		}
+		require.False(t, CopyDocumentToTerraform(ctx, want, &state.Raw).HasError())
+
+		var m types.String
+		require.False(t, state.GetAttribute(ctx, path.Root("mode"), &m).HasError())
+		require.Equal(t, "OFF", m.Value)
+		var byName types.Map
+		require.False(t, state.GetAttribute(ctx, path.Root("by_name"), &byName).HasError())
+		require.Equal(t, types.String{Value: "ON"}, byName.Elems["a"])
+
+		got := &Document{}
+		require.False(t, CopyDocumentFromTerraform(ctx, state.Raw, got).HasError())
+		require.True(t, proto.Equal(want, got), got)
+	})
+
+	t.Run("Timestamp", func(t *testing.T) {
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		want := &Document{UpdateTime: timestamppb.New(time.Date(2022, 10, 19, 15, 4, 5, 500000000, time.UTC))}
+		require.False(t, CopyDocumentToTerraform(ctx, want, &state.Raw).HasError())
+
+		var updated types.String
+		require.False(t, state.GetAttribute(ctx, path.Root("update_time"), &updated).HasError())
+		require.Equal(t, "2022-10-19T15:04:05.5Z", updated.Value)
+
+		got := &Document{}
+		require.False(t, CopyDocumentFromTerraform(ctx, state.Raw, got).HasError())
+		require.True(t, proto.Equal(want, got), got)
+
+		require.False(t, state.SetAttribute(ctx, path.Root("update_time"), "not a time").HasError())
+		diags := CopyDocumentFromTerraform(ctx, state.Raw, &Document{})
+		require.True(t, diags.HasError())
+		require.Equal(t, `"not a time" is not an RFC 3339 timestamp, e.g. 2022-10-19T15:04:05Z`, diags[0].Detail())
+	})
+
+	t.Run("Semantically equal prior values are kept", func(t *testing.T) {
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		require.False(t, state.SetAttribute(ctx, path.Root("metadata"), `{ "b": [1, 2], "a": 1.0 }`).HasError())
+		require.False(t, state.SetAttribute(ctx, path.Root("update_time"), "2022-10-19T17:04:05.500+02:00").HasError())
+		prior := state.Raw.Copy()
+
+		doc := &Document{
+			Metadata:   `{"a":1,"b":[1,2]}`,
+			UpdateTime: timestamppb.New(time.Date(2022, 10, 19, 15, 4, 5, 500000000, time.UTC)),
+		}
+		require.False(t, CopyDocumentToTerraform(ctx, doc, &state.Raw).HasError())
+		require.True(t, prior.Equal(state.Raw), state.Raw)
+
+		doc.Metadata = `{"a":2}`
+		doc.UpdateTime = timestamppb.New(time.Date(2022, 10, 19, 15, 4, 6, 0, time.UTC))
+		require.False(t, CopyDocumentToTerraform(ctx, doc, &state.Raw).HasError())
+		var metadata, updated types.String
+		require.False(t, state.GetAttribute(ctx, path.Root("metadata"), &metadata).HasError())
+		require.False(t, state.GetAttribute(ctx, path.Root("update_time"), &updated).HasError())
+		require.Equal(t, `{"a":2}`, metadata.Value)
+		require.Equal(t, "2022-10-19T15:04:06Z", updated.Value)
+	})
+
+	t.Run("Unknown name", func(t *testing.T) {
+		// Terraform validates names before they are copied, so set the raw value directly.
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		require.False(t, CopyDocumentToTerraform(ctx, &Document{}, &state.Raw).HasError())
+		require.NoError(t, runtime.SetStringAttributes(&state.Raw, map[string]string{"mode": "DIM"}))
+		diags := CopyDocumentFromTerraform(ctx, state.Raw, &Document{})
+		require.True(t, diags.HasError())
+		require.Equal(t, `"DIM" is not a test.Mode value`, diags[0].Detail())
+	})
+
+	t.Run("Unknown number", func(t *testing.T) {
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		diags := CopyDocumentToTerraform(ctx, &Document{Mode: Mode(7)}, &state.Raw)
+		require.True(t, diags.HasError())
+		require.Equal(t, "7 is not a test.Mode value", diags[0].Detail())
+	})
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ xattr.TypeWithValidate = JSONType{}
+
+// JSONType is referenced as a custom type by terraform.yaml, it is a string type that only accepts valid JSON.
+type JSONType struct{}
+
+func (t JSONType) TerraformType(ctx context.Context) tftypes.Type {
+	return types.StringType.TerraformType(ctx)
+}
+
+func (t JSONType) ValueFromTerraform(ctx context.Context, value tftypes.Value) (attr.Value, error) {
+	return types.StringType.ValueFromTerraform(ctx, value)
+}
+
+func (t JSONType) ValueType(ctx context.Context) attr.Value {
+	return types.StringType.ValueType(ctx)
+}
+
+func (t JSONType) Equal(o attr.Type) bool {
+	_, ok := o.(JSONType)
+	return ok
+}
+
+func (t JSONType) String() string {
+	return "JSONType"
+}
+
+func (t JSONType) ApplyTerraform5AttributePathStep(step tftypes.AttributePathStep) (interface{}, error) {
+	return nil, fmt.Errorf("cannot apply AttributePathStep %T to %s", step, t)
+}
+
+// Validate rejects strings that aren't valid JSON.
+func (t JSONType) Validate(ctx context.Context, value tftypes.Value, p path.Path) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	if value.IsNull() || !value.IsKnown() {
+		return diags
+	}
+	var s string
+	if err := value.As(&s); err != nil {
+		diags.AddAttributeError(p, "Invalid JSON", err.Error())
+		return diags
+	}
+	if !json.Valid([]byte(s)) {
+		diags.AddAttributeError(p, "Invalid JSON", fmt.Sprintf("%q is not valid JSON", s))
+	}
+	return diags
+}
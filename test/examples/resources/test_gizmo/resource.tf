@@ -0,0 +1,5 @@
+resource "test_gizmo" "example" {
+  # gizmo   = "example"
+  # name    = "example"
+  # project = "example"
+}
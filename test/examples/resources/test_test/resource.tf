@@ -0,0 +1,84 @@
+resource "test_test" "example" {
+  inject_required = 1
+  required        = "example"
+
+  # bool = true
+  # branch1 = {
+  #   # str = "example"
+  # }
+  # branch2 = {
+  #   # int32 = 1
+  # }
+  # branch3          = "example"
+  # bytes            = "example"
+  # double           = 1.5
+  # float            = 1.5
+  # inject_default   = 1
+  # inject_described = "example"
+  # inject_list      = ["example"]
+  # inject_nested = [{
+  #   name = "example"
+  #
+  #   # value = 1.5
+  # }]
+  # inject_object = {
+  #   name = "example"
+  #   tags = {
+  #     key = "example"
+  #   }
+  # }
+  # inject_optional = true
+  # int32           = 1
+  # int64           = 1
+  # map = {
+  #   key = "example"
+  # }
+  # mode = 1 # ON
+  # nested = {
+  #   # map = {
+  #   #   key = "example"
+  #   # }
+  #   # map_object_nested = {
+  #   #   key = {
+  #   #     # str = "example"
+  #   #   }
+  #   # }
+  #   # other_nested_list = [{
+  #   #   # str = "example"
+  #   # }]
+  #   # str = "example"
+  # }
+  # nested_list = [{
+  #   # map = {
+  #   #   key = "example"
+  #   # }
+  #   # map_object_nested = {
+  #   #   key = {
+  #   #     # str = "example"
+  #   #   }
+  #   # }
+  #   # other_nested_list = [{
+  #   #   # str = "example"
+  #   # }]
+  #   # str = "example"
+  # }]
+  # nested_map = {
+  #   key = {
+  #     # map = {
+  #     #   key = "example"
+  #     # }
+  #     # map_object_nested = {
+  #     #   key = {
+  #     #     # str = "example"
+  #     #   }
+  #     # }
+  #     # other_nested_list = [{
+  #     #   # str = "example"
+  #     # }]
+  #     # str = "example"
+  #   }
+  # }
+  # str         = "example"
+  # string_list = ["example"]
+  # struct      = jsonencode({})
+}
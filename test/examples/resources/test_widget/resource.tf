@@ -0,0 +1,12 @@
+resource "test_widget" "example" {
+  # display_name = "example"
+  # name         = "example"
+  # project      = "example"
+  # timeouts = {
+  #   # create = "30m"
+  #   # delete = "30m"
+  #   # read   = "30m"
+  #   # update = "30m"
+  # }
+  # widget_id = "example"
+}
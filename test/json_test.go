@@ -0,0 +1,90 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestJSONMessages(t *testing.T) {
+	ctx := context.Background()
+	schema, diags := GenSchemaBlob(ctx)
+	require.False(t, diags.HasError())
+
+	t.Run("Schema", func(t *testing.T) {
+		for _, name := range []string{"struct", "value", "list_value", "settings"} {
+			require.Equal(t, types.StringType, schema.Attributes[name].Type, name)
+		}
+		require.Equal(t, types.ListType{ElemType: types.StringType}, schema.Attributes["history"].Type)
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		fields, err := structpb.NewStruct(map[string]interface{}{"b": []interface{}{true, nil}, "a": 1.5})
+		require.NoError(t, err)
+		want := &Blob{
+			Struct:    fields,
+			Value:     structpb.NewStringValue("s"),
+			ListValue: &structpb.ListValue{Values: []*structpb.Value{structpb.NewNumberValue(1)}},
+			Settings:  &Settings{Name: "n", Size: 3},
+			History:   []*Settings{{Name: "h"}},
+		}
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		require.False(t, CopyBlobToTerraform(ctx, want, &state.Raw).HasError())
+
+		var s types.String
+		require.False(t, state.GetAttribute(ctx, path.Root("struct"), &s).HasError())
+		require.Equal(t, `{"a":1.5,"b":[true,null]}`, s.Value)
+		require.False(t, state.GetAttribute(ctx, path.Root("settings"), &s).HasError())
+		require.Equal(t, `{"name":"n","size":"3"}`, s.Value)
+
+		got := &Blob{}
+		require.False(t, CopyBlobFromTerraform(ctx, state.Raw, got).HasError())
+		require.True(t, proto.Equal(want, got), got)
+	})
+
+	t.Run("Formatting differences keep the prior value", func(t *testing.T) {
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		require.False(t, state.SetAttribute(ctx, path.Root("struct"), "{\n  \"b\": 2,\n  \"a\": 1.0\n}").HasError())
+		require.False(t, state.SetAttribute(ctx, path.Root("value"), `"old"`).HasError())
+
+		fields, err := structpb.NewStruct(map[string]interface{}{"a": 1, "b": 2})
+		require.NoError(t, err)
+		require.False(t, CopyBlobToTerraform(ctx, &Blob{Struct: fields, Value: structpb.NewStringValue("new")}, &state.Raw).HasError())
+
+		var s types.String
+		require.False(t, state.GetAttribute(ctx, path.Root("struct"), &s).HasError())
+		require.Equal(t, "{\n  \"b\": 2,\n  \"a\": 1.0\n}", s.Value)
+		require.False(t, state.GetAttribute(ctx, path.Root("value"), &s).HasError())
+		require.Equal(t, `"new"`, s.Value)
+	})
+
+	t.Run("Invalid JSON has the attribute path", func(t *testing.T) {
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		require.False(t, state.SetAttribute(ctx, path.Root("settings"), `{"unknown": 1}`).HasError())
+
+		diags := CopyBlobFromTerraform(ctx, state.Raw, &Blob{})
+		require.True(t, diags.HasError())
+		require.Equal(t, path.Root("settings"), diags.Errors()[0].(interface{ Path() path.Path }).Path())
+	})
+}
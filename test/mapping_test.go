@@ -0,0 +1,68 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestTypeMapping(t *testing.T) {
+	ctx := context.Background()
+	schema, diags := GenSchemaPrice(ctx)
+	require.False(t, diags.HasError())
+
+	t.Run("Schema", func(t *testing.T) {
+		require.Equal(t, types.StringType, schema.Attributes["amount"].Type)
+		require.Equal(t, types.ListType{ElemType: types.StringType}, schema.Attributes["history"].Type)
+		require.Equal(t, types.MapType{ElemType: types.StringType}, schema.Attributes["by_region"].Type)
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		want := &Price{
+			Amount:   &Money{CurrencyCode: "USD", Units: 12, Nanos: 500000000},
+			History:  []*Money{{CurrencyCode: "EUR", Units: 3}},
+			ByRegion: map[string]*Money{"uk": {CurrencyCode: "GBP", Units: 1, Nanos: 5}},
+		}
+		require.False(t, CopyPriceToTerraform(ctx, want, &state.Raw).HasError())
+
+		var amount types.String
+		require.False(t, state.GetAttribute(ctx, path.Root("amount"), &amount).HasError())
+		require.Equal(t, "12.50 USD", amount.Value)
+		require.False(t, state.GetAttribute(ctx, path.Root("by_region").AtMapKey("uk"), &amount).HasError())
+		require.Equal(t, "1.000000005 GBP", amount.Value)
+
+		got := &Price{}
+		require.False(t, CopyPriceFromTerraform(ctx, state.Raw, got).HasError())
+		require.True(t, proto.Equal(want, got), got)
+	})
+
+	t.Run("Conversion errors have the attribute path", func(t *testing.T) {
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		require.False(t, state.SetAttribute(ctx, path.Root("history"), []string{"12 USD", "twelve"}).HasError())
+
+		diags := CopyPriceFromTerraform(ctx, state.Raw, &Price{})
+		require.True(t, diags.HasError())
+		require.Equal(t, path.Root("history").AtListIndex(1), diags.Errors()[0].(interface{ Path() path.Path }).Path())
+	})
+}
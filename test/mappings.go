@@ -0,0 +1,54 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// MoneyToTerraform is referenced as a type mapping by terraform.yaml, it formats Money like "12.50 USD".
+func MoneyToTerraform(ctx context.Context, obj *Money) (types.String, diag.Diagnostics) {
+	fraction := strings.TrimRight(fmt.Sprintf("%09d", obj.Nanos), "0")
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+	return types.String{Value: fmt.Sprintf("%d.%s %s", obj.Units, fraction, obj.CurrencyCode)}, nil
+}
+
+// MoneyFromTerraform is referenced as a type mapping by terraform.yaml, it parses Money formatted like "12.50 USD".
+func MoneyFromTerraform(ctx context.Context, value types.String, obj *Money) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	amount, currency, ok := strings.Cut(value.Value, " ")
+	units, fraction, _ := strings.Cut(amount, ".")
+	u, err := strconv.ParseInt(units, 10, 64)
+	n, nerr := strconv.ParseInt((fraction + "000000000")[:9], 10, 32)
+	if !ok || err != nil || nerr != nil || len(fraction) > 9 {
+		diags.AddError("Invalid money", fmt.Sprintf("%q must be an amount and currency code, e.g. 12.50 USD", value.Value))
+		return diags
+	}
+	obj.Units, obj.Nanos, obj.CurrencyCode = u, int32(n), currency
+	return diags
+}
+
+// MoneySample is referenced as a type mapping by terraform.yaml, it sets the value the generated tests round trip.
+func MoneySample(obj *Money) {
+	obj.Units, obj.Nanos, obj.CurrencyCode = 12, 500000000, "USD"
+}
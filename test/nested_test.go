@@ -0,0 +1,62 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNestedMessageSchemas(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Inner", func(t *testing.T) {
+		schema, diags := GenSchemaOuter_Inner(ctx)
+		require.False(t, diags.HasError())
+		require.Equal(t, types.StringType, schema.Attributes["value"].Type)
+		require.Contains(t, schema.Attributes["deepest"].GetAttributes().GetAttributes(), "flag")
+	})
+
+	t.Run("Deepest", func(t *testing.T) {
+		schema, diags := GenSchemaOuter_Inner_Deepest(ctx)
+		require.False(t, diags.HasError())
+		require.Equal(t, types.BoolType, schema.Attributes["flag"].Type)
+	})
+
+	t.Run("Helpers", func(t *testing.T) {
+		schema, diags := GenSchemaTest(ctx)
+		require.False(t, diags.HasError())
+		require.Equal(t, tfsdk.ListNestedAttributes(attrsNested()), schema.Attributes["nested_list"].Attributes)
+		require.Equal(t, tfsdk.MapNestedAttributes(attrsNested()), schema.Attributes["nested_map"].Attributes)
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		schema, diags := GenSchemaOuter_Inner(ctx)
+		require.False(t, diags.HasError())
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+
+		want := &Outer_Inner{Value: "v", Deepest: &Outer_Inner_Deepest{Flag: true}}
+		require.False(t, CopyOuter_InnerToTerraform(ctx, want, &state.Raw).HasError())
+		got := &Outer_Inner{}
+		require.False(t, CopyOuter_InnerFromTerraform(ctx, state.Raw, got).HasError())
+		require.True(t, proto.Equal(want, got), got)
+	})
+}
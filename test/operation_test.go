@@ -0,0 +1,135 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/liamawhite/protoc-gen-terraform/pkg/runtime"
+)
+
+// operations returns each of its operations in turn, repeating the last one.
+type operations struct {
+	ops   []*longrunning.Operation
+	calls int
+}
+
+func (o *operations) GetOperation(ctx context.Context, in *longrunning.GetOperationRequest, opts ...grpc.CallOption) (*longrunning.Operation, error) {
+	op := o.ops[len(o.ops)-1]
+	if o.calls < len(o.ops) {
+		op = o.ops[o.calls]
+	}
+	o.calls++
+	return op, nil
+}
+
+func TestOperationSchema(t *testing.T) {
+	schema, diags := GenSchemaGadget(context.Background())
+	require.False(t, diags.HasError())
+
+	timeouts := schema.Attributes["timeouts"]
+	require.True(t, timeouts.Optional)
+	for _, name := range []string{"create", "read", "update", "delete"} {
+		require.Contains(t, timeouts.Attributes.GetAttributes(), name)
+	}
+
+	// Only operation responses get timeouts.
+	schema, diags = GenSchemaCreateGadgetRequest(context.Background())
+	require.False(t, diags.HasError())
+	require.NotContains(t, schema.Attributes, "timeouts")
+}
+
+func TestWaitOperation(t *testing.T) {
+	ctx := context.Background()
+	runtime.PollInterval = time.Millisecond
+	schema, diags := GenSchemaGadget(ctx)
+	require.False(t, diags.HasError())
+
+	plan := func(timeout string) tfsdk.Plan {
+		plan := tfsdk.Plan{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		if timeout != "" {
+			require.False(t, plan.SetAttribute(ctx, path.Root("timeouts").AtName("create"), timeout).HasError())
+		}
+		return plan
+	}
+
+	response, err := anypb.New(&Gadget{Name: "g1", Color: "blue"})
+	require.NoError(t, err)
+	pending := &longrunning.Operation{Name: "operations/1"}
+	done := &longrunning.Operation{Name: "operations/1", Done: true, Result: &longrunning.Operation_Response{Response: response}}
+
+	t.Run("Response", func(t *testing.T) {
+		client := &operations{ops: []*longrunning.Operation{pending, done}}
+		gadget, diags := WaitCreateGadget(ctx, client, pending, plan(""))
+		require.False(t, diags.HasError(), diags)
+		require.True(t, proto.Equal(&Gadget{Name: "g1", Color: "blue"}, gadget))
+		require.Equal(t, 2, client.calls)
+	})
+
+	t.Run("Already done", func(t *testing.T) {
+		gadget, diags := WaitCreateGadget(ctx, &operations{}, done, nil)
+		require.False(t, diags.HasError(), diags)
+		require.Equal(t, "g1", gadget.Name)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		failed := &longrunning.Operation{Name: "operations/1", Done: true, Result: &longrunning.Operation_Error{
+			Error: &status.Status{Code: int32(codes.AlreadyExists), Message: "gadget g1 already exists"},
+		}}
+		_, diags := WaitCreateGadget(ctx, &operations{ops: []*longrunning.Operation{failed}}, pending, plan(""))
+		require.True(t, diags.HasError())
+		require.Contains(t, diags[0].Detail(), "AlreadyExists: gadget g1 already exists")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		_, diags := WaitCreateGadget(ctx, &operations{ops: []*longrunning.Operation{pending}}, pending, plan("20ms"))
+		require.True(t, diags.HasError())
+		require.Equal(t, "Operation timed out", diags[0].Summary())
+	})
+
+	t.Run("Configured timeout", func(t *testing.T) {
+		// ImportGadget is mapped to the create timeout in terraform.yaml.
+		_, diags := WaitImportGadget(ctx, &operations{ops: []*longrunning.Operation{pending}}, pending, plan("20ms"))
+		require.True(t, diags.HasError())
+		require.Equal(t, "Operation timed out", diags[0].Summary())
+	})
+
+	t.Run("Invalid timeout", func(t *testing.T) {
+		_, diags := WaitCreateGadget(ctx, &operations{ops: []*longrunning.Operation{done}}, pending, plan("soon"))
+		require.True(t, diags.HasError())
+		require.Equal(t, "Invalid timeout", diags[0].Summary())
+	})
+
+	t.Run("Empty response", func(t *testing.T) {
+		empty, err := anypb.New(&longrunning.GetOperationRequest{})
+		require.NoError(t, err)
+		deleted := &longrunning.Operation{Name: "operations/2", Done: true, Result: &longrunning.Operation_Response{Response: empty}}
+		_, diags := WaitDeleteGadget(ctx, &operations{}, deleted, nil)
+		require.True(t, diags.HasError(), "mismatched response types are reported")
+	})
+}
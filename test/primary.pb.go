@@ -88,6 +88,7 @@ func (Mode) EnumDescriptor() ([]byte, []int) {
 
 // Test message definition.
 // +terraform-gen:config:test.terraform.yaml
+// +terraform-gen:resource
 type Test struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -18,9 +18,16 @@ package test
 import (
 	"context"
 
+	attr "github.com/hashicorp/terraform-plugin-framework/attr"
 	diag "github.com/hashicorp/terraform-plugin-framework/diag"
+	path "github.com/hashicorp/terraform-plugin-framework/path"
+	resource "github.com/hashicorp/terraform-plugin-framework/resource"
 	tfsdk "github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	types "github.com/hashicorp/terraform-plugin-framework/types"
+	tftypes "github.com/hashicorp/terraform-plugin-go/tftypes"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	runtime "github.com/liamawhite/protoc-gen-terraform/pkg/runtime"
 )
 
 // GenSchemaTest returns tfsdk.Schema definition for Test
@@ -32,20 +39,12 @@ func GenSchemaTest(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 			Type:        types.BoolType,
 		},
 		"branch1": {
-			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{"str": {
-				Description: "Str string field",
-				Optional:    true,
-				Type:        types.StringType,
-			}}),
+			Attributes:  tfsdk.SingleNestedAttributes(attrsBranch1()),
 			Description: "Branch1 is the first oneOf branch",
 			Optional:    true,
 		},
 		"branch2": {
-			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{"int32": {
-				Description: "Int32 int field",
-				Optional:    true,
-				Type:        types.Int64Type,
-			}}),
+			Attributes:  tfsdk.SingleNestedAttributes(attrsBranch2()),
 			Description: "Branch2 is the second oneOf branch",
 			Optional:    true,
 		},
@@ -71,19 +70,51 @@ func GenSchemaTest(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 		},
 		"inject_computed": {
 			Computed: true,
-			Optional: false,
-			Required: false,
 			Type:     types.StringType,
 		},
+		"inject_default": {
+			Computed:      true,
+			Optional:      true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{runtime.DefaultValue(types.Int64{Value: 42})},
+			Type:          types.Int64Type,
+		},
+		"inject_described": {
+			Description: "Injected with a description",
+			Optional:    true,
+			Sensitive:   true,
+			Type:        types.StringType,
+		},
+		"inject_list": {
+			Optional:      true,
+			PlanModifiers: tfsdk.AttributePlanModifiers{resource.RequiresReplace()},
+			Type:          types.ListType{ElemType: types.StringType},
+			Validators:    []tfsdk.AttributeValidator{MaxItems(3)},
+		},
+		"inject_nested": {
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"name": {
+					Required: true,
+					Type:     types.StringType,
+				},
+				"value": {
+					Optional: true,
+					Type:     types.Float64Type,
+				},
+			}),
+			Optional: true,
+		},
+		"inject_object": {
+			Optional: true,
+			Type: types.ObjectType{AttrTypes: map[string]attr.Type{
+				"name": types.StringType,
+				"tags": types.MapType{ElemType: types.StringType},
+			}},
+		},
 		"inject_optional": {
-			Computed: false,
 			Optional: true,
-			Required: false,
 			Type:     types.BoolType,
 		},
 		"inject_required": {
-			Computed: false,
-			Optional: false,
 			Required: true,
 			Type:     types.Int64Type,
 		},
@@ -108,104 +139,17 @@ func GenSchemaTest(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 			Type:        types.Int64Type,
 		},
 		"nested": {
-			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
-				"map": {
-					Description: "Nested map repeated nested messages",
-					Optional:    true,
-					Type:        types.MapType{ElemType: types.StringType},
-				},
-				"map_object_nested": {
-					Attributes: tfsdk.MapNestedAttributes(map[string]tfsdk.Attribute{"str": {
-						Description: "Str string field",
-						Optional:    true,
-						Type:        types.StringType,
-					}}),
-					Description: "MapObjectNested nested object map",
-					Optional:    true,
-				},
-				"other_nested_list": {
-					Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{"str": {
-						Description: "Str string field",
-						Optional:    true,
-						Type:        types.StringType,
-					}}),
-					Description: "Nested repeated nested messages",
-					Optional:    true,
-				},
-				"str": {
-					Description: "Str string field",
-					Optional:    true,
-					Type:        types.StringType,
-				},
-			}),
+			Attributes:  tfsdk.SingleNestedAttributes(attrsNested()),
 			Description: "Nested nested message field, non-nullable",
 			Optional:    true,
 		},
 		"nested_list": {
-			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
-				"map": {
-					Description: "Nested map repeated nested messages",
-					Optional:    true,
-					Type:        types.MapType{ElemType: types.StringType},
-				},
-				"map_object_nested": {
-					Attributes: tfsdk.MapNestedAttributes(map[string]tfsdk.Attribute{"str": {
-						Description: "Str string field",
-						Optional:    true,
-						Type:        types.StringType,
-					}}),
-					Description: "MapObjectNested nested object map",
-					Optional:    true,
-				},
-				"other_nested_list": {
-					Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{"str": {
-						Description: "Str string field",
-						Optional:    true,
-						Type:        types.StringType,
-					}}),
-					Description: "Nested repeated nested messages",
-					Optional:    true,
-				},
-				"str": {
-					Description: "Str string field",
-					Optional:    true,
-					Type:        types.StringType,
-				},
-			}),
+			Attributes:  tfsdk.ListNestedAttributes(attrsNested()),
 			Description: "NestedList nested message array",
 			Optional:    true,
 		},
 		"nested_map": {
-			Attributes: tfsdk.MapNestedAttributes(map[string]tfsdk.Attribute{
-				"map": {
-					Description: "Nested map repeated nested messages",
-					Optional:    true,
-					Type:        types.MapType{ElemType: types.StringType},
-				},
-				"map_object_nested": {
-					Attributes: tfsdk.MapNestedAttributes(map[string]tfsdk.Attribute{"str": {
-						Description: "Str string field",
-						Optional:    true,
-						Type:        types.StringType,
-					}}),
-					Description: "MapObjectNested nested object map",
-					Optional:    true,
-				},
-				"other_nested_list": {
-					Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{"str": {
-						Description: "Str string field",
-						Optional:    true,
-						Type:        types.StringType,
-					}}),
-					Description: "Nested repeated nested messages",
-					Optional:    true,
-				},
-				"str": {
-					Description: "Str string field",
-					Optional:    true,
-					Type:        types.StringType,
-				},
-			}),
+			Attributes:  tfsdk.MapNestedAttributes(attrsNested()),
 			Description: "MapObject is the object map",
 			Optional:    true,
 		},
@@ -225,21 +169,248 @@ func GenSchemaTest(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 			Type:        types.ListType{ElemType: types.StringType},
 		},
 		"struct": {
-			Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{"fields": {
-				Description: "Unordered map of dynamically typed values.",
-				Type:        types.MapType{ElemType: types.ObjectType{}},
-			}}),
 			Description: "Structs are self referential so we need to avoid infinite recursion",
 			Optional:    true,
+			Type:        types.StringType,
 		},
 	}}, nil
 }
 
+// UpdateMaskTest returns a field mask of the Test fields whose attributes differ between state and plan
+func UpdateMaskTest(ctx context.Context, plan tfsdk.Plan, state tfsdk.State) (*fieldmaskpb.FieldMask, diag.Diagnostics) {
+	return runtime.UpdateMask(ctx, plan, state, []runtime.UpdateMaskPath{{
+		Attribute: path.Root("str"),
+		Field:     "Str",
+	}, {
+		Attribute: path.Root("int32"),
+		Field:     "Int32",
+	}, {
+		Attribute: path.Root("int64"),
+		Field:     "Int64",
+	}, {
+		Attribute: path.Root("float"),
+		Field:     "Float",
+	}, {
+		Attribute: path.Root("double"),
+		Field:     "Double",
+	}, {
+		Attribute: path.Root("bool"),
+		Field:     "Bool",
+	}, {
+		Attribute: path.Root("bytes"),
+		Field:     "Bytes",
+	}, {
+		Attribute: path.Root("string_list"),
+		Field:     "StringList",
+	}, {
+		Attribute: path.Root("nested"),
+		Field:     "Nested",
+		Fields: []runtime.UpdateMaskPath{{
+			Attribute: path.Root("nested").AtName("str"),
+			Field:     "Nested.Str",
+		}, {
+			Attribute: path.Root("nested").AtName("other_nested_list"),
+			Field:     "Nested.OtherNestedList",
+		}, {
+			Attribute: path.Root("nested").AtName("map"),
+			Field:     "Nested.Map",
+		}, {
+			Attribute: path.Root("nested").AtName("map_object_nested"),
+			Field:     "Nested.MapObjectNested",
+		}},
+	}, {
+		Attribute: path.Root("nested_list"),
+		Field:     "NestedList",
+	}, {
+		Attribute: path.Root("map"),
+		Field:     "Map",
+	}, {
+		Attribute: path.Root("nested_map"),
+		Field:     "NestedMap",
+	}, {
+		Attribute: path.Root("mode"),
+		Field:     "Mode",
+	}, {
+		Attribute: path.Root("branch1"),
+		Field:     "Branch1",
+		Fields: []runtime.UpdateMaskPath{{
+			Attribute: path.Root("branch1").AtName("str"),
+			Field:     "Branch1.Str",
+		}},
+	}, {
+		Attribute: path.Root("branch2"),
+		Field:     "Branch2",
+		Fields: []runtime.UpdateMaskPath{{
+			Attribute: path.Root("branch2").AtName("int32"),
+			Field:     "Branch2.Int32",
+		}},
+	}, {
+		Attribute: path.Root("branch3"),
+		Field:     "Branch3",
+	}, {
+		Attribute: path.Root("required"),
+		Field:     "required",
+	}, {
+		Attribute: path.Root("struct"),
+		Field:     "Struct",
+	}})
+}
+
+var copyTest = []runtime.FieldCopy{{
+	Attribute: "str",
+	Field:     "Str",
+}, {
+	Attribute: "int32",
+	Field:     "Int32",
+}, {
+	Attribute: "int64",
+	Field:     "Int64",
+}, {
+	Attribute: "float",
+	Field:     "Float",
+}, {
+	Attribute: "double",
+	Field:     "Double",
+}, {
+	Attribute: "bool",
+	Field:     "Bool",
+}, {
+	Attribute: "bytes",
+	Field:     "Bytes",
+}, {
+	Attribute: "string_list",
+	Field:     "StringList",
+}, {
+	Attribute: "nested",
+	Attributes: []runtime.FieldCopy{{
+		Attribute: "str",
+		Field:     "Str",
+	}, {
+		Attribute: "other_nested_list",
+		Attributes: []runtime.FieldCopy{{
+			Attribute: "str",
+			Field:     "Str",
+		}},
+		Field: "OtherNestedList",
+	}, {
+		Attribute: "map",
+		Field:     "Map",
+	}, {
+		Attribute: "map_object_nested",
+		Attributes: []runtime.FieldCopy{{
+			Attribute: "str",
+			Field:     "Str",
+		}},
+		Field: "MapObjectNested",
+	}},
+	Field: "Nested",
+}, {
+	Attribute: "nested_list",
+	Attributes: []runtime.FieldCopy{{
+		Attribute: "str",
+		Field:     "Str",
+	}, {
+		Attribute: "other_nested_list",
+		Attributes: []runtime.FieldCopy{{
+			Attribute: "str",
+			Field:     "Str",
+		}},
+		Field: "OtherNestedList",
+	}, {
+		Attribute: "map",
+		Field:     "Map",
+	}, {
+		Attribute: "map_object_nested",
+		Attributes: []runtime.FieldCopy{{
+			Attribute: "str",
+			Field:     "Str",
+		}},
+		Field: "MapObjectNested",
+	}},
+	Field: "NestedList",
+}, {
+	Attribute: "map",
+	Field:     "Map",
+}, {
+	Attribute: "nested_map",
+	Attributes: []runtime.FieldCopy{{
+		Attribute: "str",
+		Field:     "Str",
+	}, {
+		Attribute: "other_nested_list",
+		Attributes: []runtime.FieldCopy{{
+			Attribute: "str",
+			Field:     "Str",
+		}},
+		Field: "OtherNestedList",
+	}, {
+		Attribute: "map",
+		Field:     "Map",
+	}, {
+		Attribute: "map_object_nested",
+		Attributes: []runtime.FieldCopy{{
+			Attribute: "str",
+			Field:     "Str",
+		}},
+		Field: "MapObjectNested",
+	}},
+	Field: "NestedMap",
+}, {
+	Attribute: "mode",
+	Field:     "Mode",
+}, {
+	Attribute: "branch1",
+	Attributes: []runtime.FieldCopy{{
+		Attribute: "str",
+		Field:     "Str",
+	}},
+	Field: "Branch1",
+}, {
+	Attribute: "branch2",
+	Attributes: []runtime.FieldCopy{{
+		Attribute: "int32",
+		Field:     "Int32",
+	}},
+	Field: "Branch2",
+}, {
+	Attribute: "branch3",
+	Field:     "Branch3",
+}, {
+	Attribute: "required",
+	Field:     "required",
+}, {
+	Attribute: "struct",
+	Field:     "Struct",
+	Mapping:   runtime.JSONMapping,
+}}
+
+// CopyTestFromTerraform copies the Terraform value of a Test, e.g. plan.Raw, into obj
+func CopyTestFromTerraform(ctx context.Context, tf tftypes.Value, obj *Test) diag.Diagnostics {
+	return runtime.CopyFromTerraform(ctx, tf, obj.ProtoReflect(), copyTest)
+}
+
+// CopyTestToTerraform copies obj into the Terraform value of a Test, e.g. state.Raw
+func CopyTestToTerraform(ctx context.Context, obj *Test, tf *tftypes.Value) diag.Diagnostics {
+	return runtime.CopyToTerraform(ctx, obj.ProtoReflect(), tf, copyTest)
+}
+
 // GenSchemaEmptyMessageBranch returns tfsdk.Schema definition for EmptyMessageBranch
 func GenSchemaEmptyMessageBranch(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{Attributes: map[string]tfsdk.Attribute{}}, nil
 }
 
+var copyEmptyMessageBranch = []runtime.FieldCopy{}
+
+// CopyEmptyMessageBranchFromTerraform copies the Terraform value of a EmptyMessageBranch, e.g. plan.Raw, into obj
+func CopyEmptyMessageBranchFromTerraform(ctx context.Context, tf tftypes.Value, obj *EmptyMessageBranch) diag.Diagnostics {
+	return runtime.CopyFromTerraform(ctx, tf, obj.ProtoReflect(), copyEmptyMessageBranch)
+}
+
+// CopyEmptyMessageBranchToTerraform copies obj into the Terraform value of a EmptyMessageBranch, e.g. state.Raw
+func CopyEmptyMessageBranchToTerraform(ctx context.Context, obj *EmptyMessageBranch, tf *tftypes.Value) diag.Diagnostics {
+	return runtime.CopyToTerraform(ctx, obj.ProtoReflect(), tf, copyEmptyMessageBranch)
+}
+
 // GenSchemaNested returns tfsdk.Schema definition for Nested
 func GenSchemaNested(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{Attributes: map[string]tfsdk.Attribute{
@@ -249,20 +420,12 @@ func GenSchemaNested(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 			Type:        types.MapType{ElemType: types.StringType},
 		},
 		"map_object_nested": {
-			Attributes: tfsdk.MapNestedAttributes(map[string]tfsdk.Attribute{"str": {
-				Description: "Str string field",
-				Optional:    true,
-				Type:        types.StringType,
-			}}),
+			Attributes:  tfsdk.MapNestedAttributes(attrsOtherNested()),
 			Description: "MapObjectNested nested object map",
 			Optional:    true,
 		},
 		"other_nested_list": {
-			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{"str": {
-				Description: "Str string field",
-				Optional:    true,
-				Type:        types.StringType,
-			}}),
+			Attributes:  tfsdk.ListNestedAttributes(attrsOtherNested()),
 			Description: "Nested repeated nested messages",
 			Optional:    true,
 		},
@@ -274,6 +437,38 @@ func GenSchemaNested(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	}}, nil
 }
 
+var copyNested = []runtime.FieldCopy{{
+	Attribute: "str",
+	Field:     "Str",
+}, {
+	Attribute: "other_nested_list",
+	Attributes: []runtime.FieldCopy{{
+		Attribute: "str",
+		Field:     "Str",
+	}},
+	Field: "OtherNestedList",
+}, {
+	Attribute: "map",
+	Field:     "Map",
+}, {
+	Attribute: "map_object_nested",
+	Attributes: []runtime.FieldCopy{{
+		Attribute: "str",
+		Field:     "Str",
+	}},
+	Field: "MapObjectNested",
+}}
+
+// CopyNestedFromTerraform copies the Terraform value of a Nested, e.g. plan.Raw, into obj
+func CopyNestedFromTerraform(ctx context.Context, tf tftypes.Value, obj *Nested) diag.Diagnostics {
+	return runtime.CopyFromTerraform(ctx, tf, obj.ProtoReflect(), copyNested)
+}
+
+// CopyNestedToTerraform copies obj into the Terraform value of a Nested, e.g. state.Raw
+func CopyNestedToTerraform(ctx context.Context, obj *Nested, tf *tftypes.Value) diag.Diagnostics {
+	return runtime.CopyToTerraform(ctx, obj.ProtoReflect(), tf, copyNested)
+}
+
 // GenSchemaOtherNested returns tfsdk.Schema definition for OtherNested
 func GenSchemaOtherNested(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{Attributes: map[string]tfsdk.Attribute{"str": {
@@ -283,6 +478,21 @@ func GenSchemaOtherNested(ctx context.Context) (tfsdk.Schema, diag.Diagnostics)
 	}}}, nil
 }
 
+var copyOtherNested = []runtime.FieldCopy{{
+	Attribute: "str",
+	Field:     "Str",
+}}
+
+// CopyOtherNestedFromTerraform copies the Terraform value of a OtherNested, e.g. plan.Raw, into obj
+func CopyOtherNestedFromTerraform(ctx context.Context, tf tftypes.Value, obj *OtherNested) diag.Diagnostics {
+	return runtime.CopyFromTerraform(ctx, tf, obj.ProtoReflect(), copyOtherNested)
+}
+
+// CopyOtherNestedToTerraform copies obj into the Terraform value of a OtherNested, e.g. state.Raw
+func CopyOtherNestedToTerraform(ctx context.Context, obj *OtherNested, tf *tftypes.Value) diag.Diagnostics {
+	return runtime.CopyToTerraform(ctx, obj.ProtoReflect(), tf, copyOtherNested)
+}
+
 // GenSchemaBranch1 returns tfsdk.Schema definition for Branch1
 func GenSchemaBranch1(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{Attributes: map[string]tfsdk.Attribute{"str": {
@@ -292,6 +502,21 @@ func GenSchemaBranch1(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	}}}, nil
 }
 
+var copyBranch1 = []runtime.FieldCopy{{
+	Attribute: "str",
+	Field:     "Str",
+}}
+
+// CopyBranch1FromTerraform copies the Terraform value of a Branch1, e.g. plan.Raw, into obj
+func CopyBranch1FromTerraform(ctx context.Context, tf tftypes.Value, obj *Branch1) diag.Diagnostics {
+	return runtime.CopyFromTerraform(ctx, tf, obj.ProtoReflect(), copyBranch1)
+}
+
+// CopyBranch1ToTerraform copies obj into the Terraform value of a Branch1, e.g. state.Raw
+func CopyBranch1ToTerraform(ctx context.Context, obj *Branch1, tf *tftypes.Value) diag.Diagnostics {
+	return runtime.CopyToTerraform(ctx, obj.ProtoReflect(), tf, copyBranch1)
+}
+
 // GenSchemaBranch2 returns tfsdk.Schema definition for Branch2
 func GenSchemaBranch2(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{Attributes: map[string]tfsdk.Attribute{"int32": {
@@ -300,3 +525,71 @@ func GenSchemaBranch2(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 		Type:        types.Int64Type,
 	}}}, nil
 }
+
+var copyBranch2 = []runtime.FieldCopy{{
+	Attribute: "int32",
+	Field:     "Int32",
+}}
+
+// CopyBranch2FromTerraform copies the Terraform value of a Branch2, e.g. plan.Raw, into obj
+func CopyBranch2FromTerraform(ctx context.Context, tf tftypes.Value, obj *Branch2) diag.Diagnostics {
+	return runtime.CopyFromTerraform(ctx, tf, obj.ProtoReflect(), copyBranch2)
+}
+
+// CopyBranch2ToTerraform copies obj into the Terraform value of a Branch2, e.g. state.Raw
+func CopyBranch2ToTerraform(ctx context.Context, obj *Branch2, tf *tftypes.Value) diag.Diagnostics {
+	return runtime.CopyToTerraform(ctx, obj.ProtoReflect(), tf, copyBranch2)
+}
+
+// attrsNested returns the nested attributes of Nested
+func attrsNested() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"map": {
+			Description: "Nested map repeated nested messages",
+			Optional:    true,
+			Type:        types.MapType{ElemType: types.StringType},
+		},
+		"map_object_nested": {
+			Attributes:  tfsdk.MapNestedAttributes(attrsOtherNested()),
+			Description: "MapObjectNested nested object map",
+			Optional:    true,
+		},
+		"other_nested_list": {
+			Attributes:  tfsdk.ListNestedAttributes(attrsOtherNested()),
+			Description: "Nested repeated nested messages",
+			Optional:    true,
+		},
+		"str": {
+			Description: "Str string field",
+			Optional:    true,
+			Type:        types.StringType,
+		},
+	}
+}
+
+// attrsOtherNested returns the nested attributes of OtherNested
+func attrsOtherNested() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{"str": {
+		Description: "Str string field",
+		Optional:    true,
+		Type:        types.StringType,
+	}}
+}
+
+// attrsBranch1 returns the nested attributes of Branch1
+func attrsBranch1() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{"str": {
+		Description: "Str string field",
+		Optional:    true,
+		Type:        types.StringType,
+	}}
+}
+
+// attrsBranch2 returns the nested attributes of Branch2
+func attrsBranch2() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{"int32": {
+		Description: "Int32 int field",
+		Optional:    true,
+		Type:        types.Int64Type,
+	}}
+}
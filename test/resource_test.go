@@ -0,0 +1,216 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	types "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liamawhite/protoc-gen-terraform/pkg/runtime"
+)
+
+func TestResourceSchema(t *testing.T) {
+	schema, diags := GenSchemaWidget(context.Background())
+	require.False(t, diags.HasError())
+
+	t.Run("ID", func(*testing.T) {
+		require.True(t, schema.Attributes["id"].Computed)
+		require.False(t, schema.Attributes["id"].Optional)
+		require.Equal(t, types.StringType, schema.Attributes["id"].Type)
+	})
+
+	t.Run("Name segments", func(*testing.T) {
+		for _, name := range []string{"project", "widget_id"} {
+			require.True(t, schema.Attributes[name].Optional)
+			require.True(t, schema.Attributes[name].Computed)
+			require.Equal(t, types.StringType, schema.Attributes[name].Type)
+		}
+	})
+
+	t.Run("Name", func(*testing.T) {
+		require.True(t, schema.Attributes["name"].Optional)
+		require.True(t, schema.Attributes["name"].Computed)
+	})
+
+	t.Run("Output only", func(*testing.T) {
+		require.False(t, schema.Attributes["state"].Optional)
+		require.True(t, schema.Attributes["state"].Computed)
+	})
+}
+
+func TestResourceName(t *testing.T) {
+	name := WidgetResourceName{Project: "p1", WidgetId: "w1"}
+	require.Equal(t, "projects/p1/widgets/w1", name.String())
+
+	parsed, err := ParseWidgetResourceName("projects/p1/widgets/w1")
+	require.NoError(t, err)
+	require.Equal(t, name, parsed)
+
+	_, err = ParseWidgetResourceName("projects/p1/gadgets/w1")
+	require.Error(t, err)
+}
+
+func TestResourceNameCopy(t *testing.T) {
+	ctx := context.Background()
+	schema, diags := GenSchemaWidget(ctx)
+	require.False(t, diags.HasError())
+
+	t.Run("Create", func(t *testing.T) {
+		// The plan of a new resource has the segments from config and an unknown id and name.
+		plan := tfsdk.Plan{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		require.False(t, plan.SetAttribute(ctx, path.Root("project"), "p1").HasError())
+		require.False(t, plan.SetAttribute(ctx, path.Root("widget_id"), "w1").HasError())
+		require.False(t, plan.SetAttribute(ctx, path.Root("display_name"), "My widget").HasError())
+		require.False(t, plan.SetAttribute(ctx, path.Root("id"), types.String{Unknown: true}).HasError())
+		require.False(t, plan.SetAttribute(ctx, path.Root("name"), types.String{Unknown: true}).HasError())
+
+		req := &Widget{}
+		require.False(t, CopyWidgetFromTerraform(ctx, plan.Raw, req).HasError())
+		require.Equal(t, "projects/p1/widgets/w1", req.Name)
+		require.Equal(t, "My widget", req.DisplayName)
+
+		state := tfsdk.State{Schema: schema, Raw: plan.Raw}
+		require.False(t, CopyWidgetToTerraform(ctx, req, &state.Raw).HasError())
+		for name, want := range map[string]string{"id": "projects/p1/widgets/w1", "name": "projects/p1/widgets/w1", "project": "p1", "widget_id": "w1"} {
+			var value types.String
+			require.False(t, state.GetAttribute(ctx, path.Root(name), &value).HasError())
+			require.False(t, value.Unknown, name)
+			require.Equal(t, want, value.Value, name)
+		}
+	})
+
+	t.Run("Name without segments", func(t *testing.T) {
+		tf := tftypes.NewValue(schema.Type().TerraformType(ctx), nil)
+		require.False(t, CopyWidgetToTerraform(ctx, &Widget{Name: "projects/p1/widgets/w1"}, &tf).HasError())
+		state := tfsdk.State{Schema: schema, Raw: tf}
+		var value types.String
+		require.False(t, state.GetAttribute(ctx, path.Root("widget_id"), &value).HasError())
+		require.Equal(t, "w1", value.Value)
+
+		got := &Widget{}
+		require.False(t, CopyWidgetFromTerraform(ctx, tf, got).HasError())
+		require.Equal(t, "projects/p1/widgets/w1", got.Name)
+	})
+
+	t.Run("Invalid name", func(t *testing.T) {
+		tf := tftypes.NewValue(schema.Type().TerraformType(ctx), nil)
+		diags := CopyWidgetToTerraform(ctx, &Widget{Name: "widgets/w1"}, &tf)
+		require.True(t, diags.HasError())
+		require.Equal(t, path.Root("id"), diags[0].(diag.DiagnosticWithPath).Path())
+	})
+}
+
+func TestResourceSegmentField(t *testing.T) {
+	ctx := context.Background()
+	schema, diags := GenSchemaGizmo(ctx)
+	require.False(t, diags.HasError())
+
+	t.Run("Schema", func(t *testing.T) {
+		// The project field is the {project} segment attribute, it keeps its own description.
+		require.Equal(t, "Project is the project the gizmo belongs to", schema.Attributes["project"].Description)
+		require.True(t, schema.Attributes["project"].Optional)
+		require.False(t, schema.Attributes["project"].Computed)
+		require.Contains(t, schema.Attributes["project"].PlanModifiers, resource.RequiresReplace())
+		require.True(t, schema.Attributes["gizmo"].Computed)
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		plan := tfsdk.Plan{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		require.False(t, plan.SetAttribute(ctx, path.Root("project"), "p1").HasError())
+		require.False(t, plan.SetAttribute(ctx, path.Root("gizmo"), "g1").HasError())
+
+		req := &Gizmo{}
+		require.False(t, CopyGizmoFromTerraform(ctx, plan.Raw, req).HasError())
+		require.Equal(t, "projects/p1/gizmos/g1", req.Name)
+		require.Equal(t, "p1", req.Project)
+
+		state := tfsdk.State{Schema: schema, Raw: plan.Raw}
+		require.False(t, CopyGizmoToTerraform(ctx, req, &state.Raw).HasError())
+		for name, want := range map[string]string{"id": "projects/p1/gizmos/g1", "project": "p1", "gizmo": "g1"} {
+			var value types.String
+			require.False(t, state.GetAttribute(ctx, path.Root(name), &value).HasError())
+			require.Equal(t, want, value.Value, name)
+		}
+	})
+}
+
+func TestImportState(t *testing.T) {
+	ctx := context.Background()
+	schema, diags := GenSchemaWidget(ctx)
+	require.False(t, diags.HasError())
+
+	for _, id := range []string{"projects/p1/widgets/w1", "p1/w1"} {
+		t.Run(id, func(t *testing.T) {
+			resp := &resource.ImportStateResponse{State: tfsdk.State{
+				Schema: schema,
+				Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+			}}
+			ImportStateWidget(ctx, resource.ImportStateRequest{ID: id}, resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var value types.String
+			require.False(t, resp.State.GetAttribute(ctx, path.Root("id"), &value).HasError())
+			require.Equal(t, "projects/p1/widgets/w1", value.Value)
+			require.False(t, resp.State.GetAttribute(ctx, path.Root("project"), &value).HasError())
+			require.Equal(t, "p1", value.Value)
+			require.False(t, resp.State.GetAttribute(ctx, path.Root("widget_id"), &value).HasError())
+			require.Equal(t, "w1", value.Value)
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schema}}
+		ImportStateWidget(ctx, resource.ImportStateRequest{ID: "w1"}, resp)
+		require.True(t, resp.Diagnostics.HasError())
+	})
+}
+
+func TestResourceTimeouts(t *testing.T) {
+	ctx := context.Background()
+	schema, diags := GenSchemaWidget(ctx)
+	require.False(t, diags.HasError())
+	require.True(t, schema.Attributes["timeouts"].Optional)
+
+	state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+	t.Run("Defaults", func(t *testing.T) {
+		create, diags := CreateTimeoutWidget(ctx, state)
+		require.False(t, diags.HasError(), diags)
+		require.Equal(t, 30*time.Minute, create)
+
+		read, diags := ReadTimeoutWidget(ctx, state)
+		require.False(t, diags.HasError(), diags)
+		require.Equal(t, runtime.DefaultTimeout, read)
+
+		del, diags := DeleteTimeoutWidget(ctx, nil)
+		require.False(t, diags.HasError(), diags)
+		require.Equal(t, 90*time.Second, del)
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		require.False(t, state.SetAttribute(ctx, path.Root("timeouts").AtName("create"), "2h45m").HasError())
+		create, diags := CreateTimeoutWidget(ctx, state)
+		require.False(t, diags.HasError(), diags)
+		require.Equal(t, 2*time.Hour+45*time.Minute, create)
+	})
+}
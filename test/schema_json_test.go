@@ -0,0 +1,115 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+type providerSchemas struct {
+	FormatVersion   string `json:"format_version"`
+	ProviderSchemas map[string]struct {
+		ResourceSchemas map[string]struct {
+			Version int64 `json:"version"`
+			Block   struct {
+				Attributes map[string]*schemaAttribute `json:"attributes"`
+			} `json:"block"`
+		} `json:"resource_schemas"`
+	} `json:"provider_schemas"`
+}
+
+type schemaAttribute struct {
+	Type       json.RawMessage `json:"type"`
+	NestedType *struct {
+		Attributes  map[string]*schemaAttribute `json:"attributes"`
+		NestingMode string                      `json:"nesting_mode"`
+	} `json:"nested_type"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+	Optional    bool   `json:"optional"`
+	Computed    bool   `json:"computed"`
+	Sensitive   bool   `json:"sensitive"`
+}
+
+// The JSON schemas must describe exactly the same attributes as the generated Go schemas.
+func TestSchemaJSON(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		file     string
+		resource string
+		schema   func(context.Context) (tfsdk.Schema, diag.Diagnostics)
+	}{
+		{"primary_terraform.schema.json", "test_test", GenSchemaTest},
+		{"resource_terraform.schema.json", "test_widget", GenSchemaWidget},
+		{"operation_terraform.schema.json", "test_gadget", GenSchemaGadget},
+		{"upgrade_terraform.schema.json", "test_versioned", GenSchemaVersioned},
+		{"nested_terraform.schema.json", "test_outer_inner", GenSchemaOuter_Inner},
+	}
+	for _, tt := range tests {
+		t.Run(tt.resource, func(t *testing.T) {
+			contents, err := ioutil.ReadFile(tt.file)
+			require.NoError(t, err)
+			var schemas providerSchemas
+			require.NoError(t, json.Unmarshal(contents, &schemas))
+			require.Equal(t, "1.0", schemas.FormatVersion)
+
+			resource := schemas.ProviderSchemas["registry.terraform.io/liamawhite/test"].ResourceSchemas[tt.resource]
+			schema, diags := tt.schema(ctx)
+			require.False(t, diags.HasError())
+			require.Equal(t, schema.Version, resource.Version)
+			requireAttributesMatch(t, ctx, schema.Attributes, resource.Block.Attributes)
+		})
+	}
+}
+
+func requireAttributesMatch(t *testing.T, ctx context.Context, attrs map[string]tfsdk.Attribute, jsonAttrs map[string]*schemaAttribute) {
+	require.Len(t, jsonAttrs, len(attrs))
+	for name, attr := range attrs {
+		jsonAttr := jsonAttrs[name]
+		require.NotNil(t, jsonAttr, name)
+		require.Equal(t, attr.Description, jsonAttr.Description, name)
+		require.Equal(t, []bool{attr.Required, attr.Optional, attr.Computed, attr.Sensitive},
+			[]bool{jsonAttr.Required, jsonAttr.Optional, jsonAttr.Computed, jsonAttr.Sensitive}, name)
+
+		if attr.Attributes != nil {
+			require.NotNil(t, jsonAttr.NestedType, name)
+			require.Equal(t, nestingModes[jsonAttr.NestedType.NestingMode], attr.Attributes.GetNestingMode(), name)
+			nested := map[string]tfsdk.Attribute{}
+			for nestedName, nestedAttr := range attr.Attributes.GetAttributes() {
+				nested[nestedName] = nestedAttr.(tfsdk.Attribute)
+			}
+			requireAttributesMatch(t, ctx, nested, jsonAttr.NestedType.Attributes)
+			continue
+		}
+		typ, err := tftypes.ParseJSONType(jsonAttr.Type) //nolint:staticcheck
+		require.NoError(t, err, name)
+		require.True(t, attr.Type.TerraformType(ctx).Equal(typ), "%s: %s != %s", name, attr.Type.TerraformType(ctx), typ)
+	}
+}
+
+var nestingModes = map[string]interface{}{
+	"single": tfsdk.SingleNestedAttributes(nil).GetNestingMode(),
+	"list":   tfsdk.ListNestedAttributes(nil).GetNestingMode(),
+	"map":    tfsdk.MapNestedAttributes(nil).GetNestingMode(),
+	"set":    tfsdk.SetNestedAttributes(nil).GetNestingMode(),
+}
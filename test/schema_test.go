@@ -18,6 +18,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	types "github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/require"
 )
@@ -37,6 +40,27 @@ func TestSchema(t *testing.T) {
 		require.True(t, schema.Attributes["inject_computed"].Computed)
 		require.True(t, schema.Attributes["inject_required"].Required)
 		require.True(t, schema.Attributes["inject_optional"].Optional)
+
+		described := schema.Attributes["inject_described"]
+		require.Equal(t, "Injected with a description", described.Description)
+		require.True(t, described.Sensitive)
+
+		require.Len(t, schema.Attributes["inject_default"].PlanModifiers, 1)
+
+		list := schema.Attributes["inject_list"]
+		require.Equal(t, types.ListType{ElemType: types.StringType}, list.Type)
+		require.Equal(t, []tfsdk.AttributeValidator{MaxItems(3)}, list.Validators)
+		require.Equal(t, tfsdk.AttributePlanModifiers{resource.RequiresReplace()}, list.PlanModifiers)
+
+		require.Equal(t, types.ObjectType{AttrTypes: map[string]attr.Type{
+			"name": types.StringType,
+			"tags": types.MapType{ElemType: types.StringType},
+		}}, schema.Attributes["inject_object"].Type)
+
+		nested := schema.Attributes["inject_nested"].Attributes
+		require.Equal(t, tfsdk.ListNestedAttributes(nil).GetNestingMode(), nested.GetNestingMode())
+		require.True(t, nested.GetAttributes()["name"].IsRequired())
+		require.Equal(t, types.Float64Type, nested.GetAttributes()["value"].GetType())
 	})
 
 	t.Run("Primitive types", func(*testing.T) {
@@ -91,3 +115,23 @@ func TestSchemaMultipleFiles(t *testing.T) {
 	require.False(t, diags.HasError())
 	require.Equal(t, types.StringType, schema.Attributes["str"].Type)
 }
+
+func TestSchemaProjectConfig(t *testing.T) {
+	schema, diags := GenSchemaGlobal(context.Background())
+	require.False(t, diags.HasError())
+
+	t.Run("Rename", func(*testing.T) {
+		require.Equal(t, types.StringType, schema.Attributes["new_name"].Type)
+		require.NotContains(t, schema.Attributes, "renamed")
+	})
+
+	t.Run("Exclude", func(*testing.T) {
+		require.NotContains(t, schema.Attributes, "excluded")
+	})
+
+	t.Run("Message config takes precedence", func(*testing.T) {
+		require.Equal(t, types.BoolType, schema.Attributes["precedence"].Type)
+		require.True(t, schema.Attributes["precedence"].Optional)
+		require.False(t, schema.Attributes["precedence"].Computed)
+	})
+}
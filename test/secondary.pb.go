@@ -84,17 +84,90 @@ func (x *Test2) GetStr() string {
 	return ""
 }
 
+// Validates the project wide config in terraform.yaml is merged with the message config.
+// +terraform-gen:config:secondary.terraform.yaml
+type Global struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Str string field
+	Str string `protobuf:"bytes,1,opt,name=Str,proto3" json:"Str,omitempty"`
+	// Renamed is renamed by the project wide config
+	Renamed string `protobuf:"bytes,2,opt,name=Renamed,proto3" json:"Renamed,omitempty"`
+	// Excluded is excluded by the project wide config
+	Excluded string `protobuf:"bytes,3,opt,name=Excluded,proto3" json:"Excluded,omitempty"`
+}
+
+func (x *Global) Reset() {
+	*x = Global{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_secondary_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Global) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Global) ProtoMessage() {}
+
+func (x *Global) ProtoReflect() protoreflect.Message {
+	mi := &file_test_secondary_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Global.ProtoReflect.Descriptor instead.
+func (*Global) Descriptor() ([]byte, []int) {
+	return file_test_secondary_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Global) GetStr() string {
+	if x != nil {
+		return x.Str
+	}
+	return ""
+}
+
+func (x *Global) GetRenamed() string {
+	if x != nil {
+		return x.Renamed
+	}
+	return ""
+}
+
+func (x *Global) GetExcluded() string {
+	if x != nil {
+		return x.Excluded
+	}
+	return ""
+}
+
 var File_test_secondary_proto protoreflect.FileDescriptor
 
 var file_test_secondary_proto_rawDesc = []byte{
 	0x0a, 0x14, 0x74, 0x65, 0x73, 0x74, 0x2f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x61, 0x72, 0x79,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x74, 0x65, 0x73, 0x74, 0x22, 0x19, 0x0a, 0x05,
 	0x54, 0x65, 0x73, 0x74, 0x32, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x74, 0x72, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x53, 0x74, 0x72, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69, 0x61, 0x6d, 0x61, 0x77, 0x68, 0x69, 0x74, 0x65,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x74, 0x65, 0x72, 0x72,
-	0x61, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x28, 0x09, 0x52, 0x03, 0x53, 0x74, 0x72, 0x22, 0x50, 0x0a, 0x06, 0x47, 0x6c, 0x6f, 0x62, 0x61,
+	0x6c, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x74, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x53, 0x74, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69, 0x61, 0x6d, 0x61, 0x77, 0x68, 0x69,
+	0x74, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x74, 0x65,
+	0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -109,9 +182,10 @@ func file_test_secondary_proto_rawDescGZIP() []byte {
 	return file_test_secondary_proto_rawDescData
 }
 
-var file_test_secondary_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_test_secondary_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_test_secondary_proto_goTypes = []interface{}{
-	(*Test2)(nil), // 0: test.Test2
+	(*Test2)(nil),  // 0: test.Test2
+	(*Global)(nil), // 1: test.Global
 }
 var file_test_secondary_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -139,6 +213,18 @@ func file_test_secondary_proto_init() {
 				return nil
 			}
 		}
+		file_test_secondary_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Global); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -146,7 +232,7 @@ func file_test_secondary_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_test_secondary_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
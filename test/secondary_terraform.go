@@ -21,6 +21,9 @@ import (
 	diag "github.com/hashicorp/terraform-plugin-framework/diag"
 	tfsdk "github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	types "github.com/hashicorp/terraform-plugin-framework/types"
+	tftypes "github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	runtime "github.com/liamawhite/protoc-gen-terraform/pkg/runtime"
 )
 
 // GenSchemaTest2 returns tfsdk.Schema definition for Test2
@@ -31,3 +34,56 @@ func GenSchemaTest2(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 		Type:        types.StringType,
 	}}}, nil
 }
+
+var copyTest2 = []runtime.FieldCopy{{
+	Attribute: "str",
+	Field:     "Str",
+}}
+
+// CopyTest2FromTerraform copies the Terraform value of a Test2, e.g. plan.Raw, into obj
+func CopyTest2FromTerraform(ctx context.Context, tf tftypes.Value, obj *Test2) diag.Diagnostics {
+	return runtime.CopyFromTerraform(ctx, tf, obj.ProtoReflect(), copyTest2)
+}
+
+// CopyTest2ToTerraform copies obj into the Terraform value of a Test2, e.g. state.Raw
+func CopyTest2ToTerraform(ctx context.Context, obj *Test2, tf *tftypes.Value) diag.Diagnostics {
+	return runtime.CopyToTerraform(ctx, obj.ProtoReflect(), tf, copyTest2)
+}
+
+// GenSchemaGlobal returns tfsdk.Schema definition for Global
+func GenSchemaGlobal(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{Attributes: map[string]tfsdk.Attribute{
+		"new_name": {
+			Description: "Renamed is renamed by the project wide config",
+			Optional:    true,
+			Type:        types.StringType,
+		},
+		"precedence": {
+			Optional: true,
+			Type:     types.BoolType,
+		},
+		"str": {
+			Description: "Str string field",
+			Optional:    true,
+			Type:        types.StringType,
+		},
+	}}, nil
+}
+
+var copyGlobal = []runtime.FieldCopy{{
+	Attribute: "str",
+	Field:     "Str",
+}, {
+	Attribute: "new_name",
+	Field:     "Renamed",
+}}
+
+// CopyGlobalFromTerraform copies the Terraform value of a Global, e.g. plan.Raw, into obj
+func CopyGlobalFromTerraform(ctx context.Context, tf tftypes.Value, obj *Global) diag.Diagnostics {
+	return runtime.CopyFromTerraform(ctx, tf, obj.ProtoReflect(), copyGlobal)
+}
+
+// CopyGlobalToTerraform copies obj into the Terraform value of a Global, e.g. state.Raw
+func CopyGlobalToTerraform(ctx context.Context, obj *Global, tf *tftypes.Value) diag.Diagnostics {
+	return runtime.CopyToTerraform(ctx, obj.ProtoReflect(), tf, copyGlobal)
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/liamawhite/protoc-gen-terraform/test/other"
+)
+
+func TestCrossPackage(t *testing.T) {
+	ctx := context.Background()
+	schema, diags := GenSchemaConsumer(ctx)
+	require.False(t, diags.HasError())
+
+	t.Run("Schema uses the other package's helper", func(t *testing.T) {
+		require.Equal(t, tfsdk.SingleNestedAttributes(other.GenAttributesShared()), schema.Attributes["shared"].Attributes)
+		require.Equal(t, tfsdk.ListNestedAttributes(other.GenAttributesShared()), schema.Attributes["shared_list"].Attributes)
+	})
+
+	t.Run("Config is resolved relative to the message's own file", func(t *testing.T) {
+		attrs := other.GenAttributesShared()
+		require.Contains(t, attrs, "shared_name")
+		require.NotContains(t, attrs, "name")
+		require.NotContains(t, attrs, "internal")
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		want := &Consumer{
+			Shared:     &other.Shared{Name: "a"},
+			SharedList: []*other.Shared{{Name: "b"}},
+		}
+		require.False(t, CopyConsumerToTerraform(ctx, want, &state.Raw).HasError())
+
+		var s types.String
+		require.False(t, state.GetAttribute(ctx, path.Root("shared").AtName("shared_name"), &s).HasError())
+		require.Equal(t, "a", s.Value)
+
+		got := &Consumer{}
+		require.False(t, CopyConsumerFromTerraform(ctx, state.Raw, got).HasError())
+		require.True(t, proto.Equal(want, got), got)
+	})
+}
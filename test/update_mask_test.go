@@ -0,0 +1,94 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateMask(t *testing.T) {
+	ctx := context.Background()
+	schema, diags := GenSchemaTest(ctx)
+	require.False(t, diags.HasError())
+
+	// set builds a plan or state by setting each attribute on an empty object.
+	set := func(values map[string]interface{}) tfsdk.State {
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		for _, p := range []path.Path{
+			path.Root("str"),
+			path.Root("int32"),
+			path.Root("nested").AtName("str"),
+			path.Root("nested").AtName("map"),
+		} {
+			if value, ok := values[p.String()]; ok {
+				require.False(t, state.SetAttribute(ctx, p, value).HasError())
+			}
+		}
+		return state
+	}
+
+	t.Run("Changed fields", func(t *testing.T) {
+		state := set(map[string]interface{}{"str": "a", "nested.str": "x"})
+		plan := set(map[string]interface{}{"str": "b", "int32": 1, "nested.str": "x", "nested.map": map[string]string{"k": "v"}})
+
+		mask, diags := UpdateMaskTest(ctx, tfsdk.Plan(plan), state)
+		require.False(t, diags.HasError(), diags)
+		require.Equal(t, []string{"Str", "Int32", "Nested.Map"}, mask.Paths)
+	})
+
+	t.Run("Message set", func(t *testing.T) {
+		state := set(map[string]interface{}{"str": "a"})
+		plan := set(map[string]interface{}{"str": "a", "nested.str": "x"})
+
+		mask, diags := UpdateMaskTest(ctx, tfsdk.Plan(plan), state)
+		require.False(t, diags.HasError(), diags)
+		require.Equal(t, []string{"Nested"}, mask.Paths)
+	})
+
+	t.Run("No changes", func(t *testing.T) {
+		state := set(map[string]interface{}{"str": "a", "nested.str": "x"})
+
+		mask, diags := UpdateMaskTest(ctx, tfsdk.Plan(state), state)
+		require.False(t, diags.HasError(), diags)
+		require.Empty(t, mask.Paths)
+	})
+}
+
+func TestUpdateMaskResource(t *testing.T) {
+	ctx := context.Background()
+	schema, diags := GenSchemaWidget(ctx)
+	require.False(t, diags.HasError())
+
+	set := func(values map[string]string) tfsdk.State {
+		state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		for name, value := range values {
+			require.False(t, state.SetAttribute(ctx, path.Root(name), value).HasError())
+		}
+		return state
+	}
+
+	// The name and output only state are never updated, even if they differ.
+	state := set(map[string]string{"name": "projects/p1/widgets/w1", "display_name": "a", "state": "ACTIVE"})
+	plan := set(map[string]string{"name": "projects/p1/widgets/w2", "display_name": "b", "state": "CREATING"})
+	mask, diags := UpdateMaskWidget(ctx, tfsdk.Plan(plan), state)
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, []string{"display_name"}, mask.Paths)
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeState(t *testing.T) {
+	ctx := context.Background()
+	schema, diags := GenSchemaVersioned(ctx)
+	require.False(t, diags.HasError())
+	require.Equal(t, int64(1), schema.Version)
+
+	upgraders := UpgradeStateVersioned(ctx)
+	require.Len(t, upgraders, 1)
+	upgrader := upgraders[0]
+	require.Contains(t, upgrader.PriorSchema.Attributes, "legacy")
+
+	prior := tfsdk.State{Schema: *upgrader.PriorSchema, Raw: tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil)}
+	for _, attr := range []struct {
+		path  path.Path
+		value interface{}
+	}{
+		{path.Root("title"), "My title"},
+		{path.Root("size"), 42},
+		{path.Root("legacy"), "gone"},
+		{path.Root("dimensions").AtName("width"), "10"},
+	} {
+		require.False(t, prior.SetAttribute(ctx, attr.path, attr.value).HasError())
+	}
+
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: schema}}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &prior}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	get := func(p path.Path, target interface{}) {
+		require.False(t, resp.State.GetAttribute(ctx, p, target).HasError())
+	}
+	t.Run("Renamed", func(t *testing.T) {
+		var title types.String
+		get(path.Root("display_title"), &title)
+		require.Equal(t, "My title", title.Value)
+	})
+
+	t.Run("Type changes", func(t *testing.T) {
+		var size types.String
+		get(path.Root("size"), &size)
+		require.Equal(t, "42", size.Value)
+
+		var width, height types.Int64
+		get(path.Root("dimensions").AtName("width"), &width)
+		require.Equal(t, int64(10), width.Value)
+		get(path.Root("dimensions").AtName("height"), &height)
+		require.True(t, height.Null)
+	})
+
+	t.Run("New attributes", func(t *testing.T) {
+		var enabled types.Bool
+		get(path.Root("enabled"), &enabled)
+		require.True(t, enabled.Null)
+	})
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// MaxItems is referenced as a validator by test.terraform.yaml.
+func MaxItems(max int) tfsdk.AttributeValidator {
+	return maxItems{max: max}
+}
+
+type maxItems struct {
+	max int
+}
+
+func (v maxItems) Description(context.Context) string {
+	return fmt.Sprintf("list must have at most %d items", v.max)
+}
+
+func (v maxItems) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v maxItems) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	list, ok := req.AttributeConfig.(types.List)
+	if !ok || list.Null || list.Unknown {
+		return
+	}
+	if len(list.Elems) > v.max {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Too many items", v.Description(ctx))
+	}
+}